@@ -77,6 +77,7 @@ prng_init_prng_from_seed(prng_context *sc,
 {
 	prng_init(sc);
 	prng_inject(sc, seed, seed_len);
+	prng_flip(sc);
 }
 
 /* see falcon.h */
@@ -90,6 +91,7 @@ prng_init_prng_from_system(prng_context *sc)
 	}
 	prng_init(sc);
 	prng_inject(sc, seed, sizeof seed);
+	prng_flip(sc);
 	return 0;
 }
 
@@ -28,7 +28,7 @@ func main() {
 	// Try different signature types
 	sigTypes := []struct {
 		name string
-		typ  int
+		typ  falcon.SignatureType
 	}{
 		{"Compressed", falcon.SigCompressed},
 		{"Padded", falcon.SigPadded},
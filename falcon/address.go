@@ -0,0 +1,51 @@
+package falcon
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// AddressSize is the length in bytes of an address derived by Address
+const AddressSize = 20
+
+// Address derives a fixed-size identifier from a public key: the last
+// AddressSize bytes of SHAKE256(publicKey) squeezed to 32 bytes. The
+// digest is always SHAKE256, even in builds whose PRNG is Keccak-256, so
+// addresses do not depend on the build.
+func Address(publicKey []byte) ([]byte, error) {
+	if _, err := checkPublicKey(publicKey); err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	digest := fixedShake256(32, publicKey)
+	return digest[len(digest)-AddressSize:], nil
+}
+
+// AddressString returns the address of publicKey as 0x-prefixed hex with
+// a mixed-case checksum. As in EIP-55, each letter is upper-cased when
+// the matching nibble of a digest of the lower-case hex is 8 or more,
+// but the digest is SHAKE256 rather than Keccak-256.
+func AddressString(publicKey []byte) (string, error) {
+	addr, err := Address(publicKey)
+	if err != nil {
+		return "", err
+	}
+	return "0x" + checksumHex(hex.EncodeToString(addr)), nil
+}
+
+// checksumHex applies the mixed-case checksum to lower-case hex
+func checksumHex(lower string) string {
+	digest := fixedShake256(32, []byte(lower))
+	var b strings.Builder
+	for i, c := range lower {
+		nibble := digest[i/2] >> 4
+		if i%2 == 1 {
+			nibble = digest[i/2] & 0x0F
+		}
+		if c >= 'a' && nibble >= 8 {
+			c -= 'a' - 'A'
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,74 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestAddress(t *testing.T) {
+	keys, err := TestKeys(bytes.Repeat([]byte{0x07}, 32))
+	if err != nil {
+		t.Fatalf("Failed to generate test keys: %v", err)
+	}
+
+	seen := map[string]bool{}
+	for logN, keyPair := range keys {
+		addr, err := Address(keyPair.PublicKey)
+		if err != nil {
+			t.Fatalf("Failed to derive address: %v", err)
+		}
+		if len(addr) != AddressSize {
+			t.Fatalf("Expected %d-byte address, got %d", AddressSize, len(addr))
+		}
+		again, err := Address(keyPair.PublicKey)
+		if err != nil || !bytes.Equal(addr, again) {
+			t.Fatalf("Address for logN %d is not deterministic", logN)
+		}
+		if seen[string(addr)] {
+			t.Fatalf("Distinct keys produced the same address")
+		}
+		seen[string(addr)] = true
+
+		s, err := AddressString(keyPair.PublicKey)
+		if err != nil {
+			t.Fatalf("Failed to format address: %v", err)
+		}
+		if len(s) != 2+2*AddressSize || !strings.HasPrefix(s, "0x") {
+			t.Fatalf("Unexpected address string %q", s)
+		}
+		if s2, _ := AddressString(keyPair.PublicKey); s2 != s {
+			t.Fatalf("Address string is not deterministic: %q vs %q", s, s2)
+		}
+		if checksumHex(hex.EncodeToString(addr)) != s[2:] {
+			t.Fatalf("Address string %q does not match its address", s)
+		}
+	}
+
+	if _, err := Address(keys[9].PrivateKey); err == nil {
+		t.Fatal("Expected error deriving an address from a private key")
+	}
+	if _, err := AddressString(nil); err == nil {
+		t.Fatal("Expected error for empty public key")
+	}
+}
+
+// fixedShake256 must be standard SHAKE256 whatever PRNG the C library
+// was built with
+func TestFixedShake256(t *testing.T) {
+	for _, tc := range []struct {
+		data []byte
+		want string
+	}{
+		{nil, "46b9dd2b0ba88d13233b3feb743eeb243fcd52ea62b81b82b50c27646ed5762f"},
+		{[]byte("abc"), "483366601360a8771c6863080cc4114d8db44530f8f1e1ee4f94ea37e78b5739"},
+		{bytes.Repeat([]byte{0xA3}, 200), "cd8a920ed141aa0407a22d59288652e9d9f1a7ee0c1e7c1ca699424da84a904d"},
+	} {
+		if got := hex.EncodeToString(fixedShake256(32, tc.data)); got != tc.want {
+			t.Errorf("fixedShake256(%x) = %s, want %s", tc.data, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package falcon
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// VerifyBatch verifies every item and returns one error per item, nil
+// for valid signatures. The items are spread over up to GOMAXPROCS
+// goroutines, each with its own scratch buffer sized for the largest
+// degree in the batch; items may mix degrees. SetMaxConcurrency still
+// bounds how many verifications run in C at once.
+func VerifyBatch(items []VerifyItem) []error {
+	var maxLogN uint
+	for _, item := range items {
+		if logN, err := checkPublicKey(item.PublicKey); err == nil && logN > maxLogN {
+			maxLogN = logN
+		}
+	}
+
+	errs := make([]error, len(items))
+	parallelFor(len(items), maxLogN, func(i int, tmp []byte) {
+		errs[i] = verifyItem(items[i], tmp)
+	})
+	return errs
+}
+
+// VerifyBatchSameKey verifies signatures[i] over messages[i] for every
+// i, all under one public key and signature type, and returns one error
+// per signature. The key is decoded and converted to NTT form once for
+// the whole batch, which is then verified in parallel as by VerifyBatch.
+// If the key is invalid, or the two slices differ in length, every slot
+// holds that error.
+func VerifyBatchSameKey(signatures, messages [][]byte, publicKey []byte, sigType int) []error {
+	errs := make([]error, len(signatures))
+	fail := func(err error) []error {
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+	if len(messages) != len(signatures) {
+		return fail(newError(ErrBadArg, fmt.Sprintf("%d signatures but %d messages", len(signatures), len(messages))))
+	}
+	key, err := PrecomputePublicKey(publicKey)
+	if err != nil {
+		return fail(err)
+	}
+
+	parallelFor(len(signatures), key.logN, func(i int, tmp []byte) {
+		errs[i] = key.verifyWithTmp(signatures[i], messages[i], sigType, tmp)
+	})
+	return errs
+}
+
+// parallelFor calls fn for every index in [0, n) from up to GOMAXPROCS
+// goroutines. Each goroutine passes fn its own verification scratch
+// buffer for degree logN, or nil if logN is 0.
+func parallelFor(n int, logN uint, fn func(i int, tmp []byte)) {
+	workers := runtime.GOMAXPROCS(0)
+	if workers > n {
+		workers = n
+	}
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			var tmp []byte
+			if logN > 0 {
+				tmp = make([]byte, tmpSizeVerify(logN))
+			}
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return
+				}
+				fn(i, tmp)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// SignBatch signs every message with privateKey, seeding one PRNG from
+// the system for the whole batch instead of one per signature. The
+// result holds one signature per message, in order. The first failure
+// aborts the batch and is returned naming the message index.
+func SignBatch(messages [][]byte, privateKey []byte, sigType int) ([][]byte, error) {
+	rng, err := newSystemPRNG()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize RNG: %w", err)
+	}
+	return SignBatchWithRNG(messages, privateKey, sigType, rng)
+}
+
+// SignBatchWithRNG is SignBatch drawing randomness from a caller-managed
+// rng, which must be in output mode, as for SignWithRNG, and is advanced
+// by every signature
+func SignBatchWithRNG(messages [][]byte, privateKey []byte, sigType int, rng *PRNGContext) ([][]byte, error) {
+	logN, err := checkPrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	if _, err := sigBufferSize(logN, sigType); err != nil {
+		return nil, err
+	}
+	if rng == nil {
+		return nil, newError(ErrBadArg, "nil PRNG context")
+	}
+	if !rng.flipped {
+		return nil, ErrContextNotFlipped
+	}
+
+	signatures := make([][]byte, len(messages))
+	for i, message := range messages {
+		signature, err := signWithPRNG(rng, message, privateKey, sigType)
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %w", i, err)
+		}
+		signatures[i] = signature
+	}
+	return signatures, nil
+}
@@ -0,0 +1,203 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestVerifyBatchMixedDegrees(t *testing.T) {
+	keys := map[uint]*KeyPair{}
+	for _, logN := range []uint{9, 10} {
+		keyPair, err := GenerateKeyPair(logN)
+		if err != nil {
+			t.Fatalf("Failed to generate key pair: %v", err)
+		}
+		keys[logN] = keyPair
+	}
+
+	// Interleave Falcon-512 and Falcon-1024 items; items 4 (512) and 7
+	// (1024) carry a modified message
+	var items []VerifyItem
+	invalid := map[int]bool{4: true, 7: true}
+	for i := 0; i < 10; i++ {
+		logN := uint(9 + i%2)
+		message := []byte(fmt.Sprintf("item %d", i))
+		signature, err := Sign(message, keys[logN].PrivateKey, SigCompressed)
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		if invalid[i] {
+			message = append(message, '?')
+		}
+		items = append(items, VerifyItem{
+			Signature: signature,
+			Message:   message,
+			PublicKey: keys[logN].PublicKey,
+			SigType:   SigCompressed,
+		})
+	}
+
+	// A malformed item must not disturb the rest
+	items = append(items, VerifyItem{Signature: []byte{0x39}, PublicKey: keys[9].PublicKey, SigType: SigCompressed})
+
+	errs := VerifyBatch(items)
+	if len(errs) != len(items) {
+		t.Fatalf("Expected %d results, got %d", len(items), len(errs))
+	}
+	for i := 0; i < 10; i++ {
+		if invalid[i] && errs[i] == nil {
+			t.Fatalf("Item %d verified with a modified message", i)
+		}
+		if !invalid[i] && errs[i] != nil {
+			t.Fatalf("Item %d failed verification: %v", i, errs[i])
+		}
+	}
+	if !errors.Is(errs[10], ErrBadFormat) {
+		t.Fatalf("Expected ErrBadFormat for malformed item, got %v", errs[10])
+	}
+
+	if errs := VerifyBatch(nil); len(errs) != 0 {
+		t.Fatalf("Expected no results for empty batch, got %d", len(errs))
+	}
+}
+
+func TestSignBatch(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	messages := make([][]byte, 20)
+	for i := range messages {
+		messages[i] = []byte(fmt.Sprintf("document %d", i))
+	}
+
+	signatures, err := SignBatch(messages, keyPair.PrivateKey, SigPadded)
+	if err != nil {
+		t.Fatalf("Failed to sign batch: %v", err)
+	}
+	if len(signatures) != len(messages) {
+		t.Fatalf("Got %d signatures for %d messages", len(signatures), len(messages))
+	}
+	for i, signature := range signatures {
+		if err := Verify(signature, messages[i], keyPair.PublicKey, SigPadded); err != nil {
+			t.Fatalf("Signature %d failed verification: %v", i, err)
+		}
+	}
+
+	// A caller-managed rng makes the batch reproducible
+	var rng1, rng2 PRNGContext
+	rng1.InitFromSeed([]byte("batch seed"))
+	rng2.InitFromSeed([]byte("batch seed"))
+	a, err := SignBatchWithRNG(messages, keyPair.PrivateKey, SigCompressed, &rng1)
+	if err != nil {
+		t.Fatalf("Failed to sign batch: %v", err)
+	}
+	b, err := SignBatchWithRNG(messages, keyPair.PrivateKey, SigCompressed, &rng2)
+	if err != nil {
+		t.Fatalf("Failed to sign batch: %v", err)
+	}
+	for i := range a {
+		if string(a[i]) != string(b[i]) {
+			t.Fatalf("Signature %d differs between identically seeded batches", i)
+		}
+	}
+
+	if _, err := SignBatch(messages, keyPair.PublicKey, SigCompressed); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("Expected ErrBadFormat, got %v", err)
+	}
+	if _, err := SignBatchWithRNG(messages, keyPair.PrivateKey, SigCompressed, nil); !errors.Is(err, ErrBadArgument) {
+		t.Fatalf("Expected ErrBadArgument for nil rng, got %v", err)
+	}
+	unflipped := &PRNGContext{}
+	unflipped.Init()
+	if _, err := SignBatchWithRNG(messages, keyPair.PrivateKey, SigCompressed, unflipped); !errors.Is(err, ErrContextNotFlipped) {
+		t.Fatalf("Expected ErrContextNotFlipped, got %v", err)
+	}
+}
+
+func TestVerifyBatchSameKey(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	var signatures, messages [][]byte
+	for i := 0; i < 25; i++ {
+		message := []byte(fmt.Sprintf("same key %d", i))
+		signature, err := Sign(message, keyPair.PrivateKey, SigCT)
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		if i%6 == 5 {
+			message = append(message, '!')
+		}
+		signatures = append(signatures, signature)
+		messages = append(messages, message)
+	}
+
+	errs := VerifyBatchSameKey(signatures, messages, keyPair.PublicKey, SigCT)
+	if len(errs) != len(signatures) {
+		t.Fatalf("Expected %d results, got %d", len(signatures), len(errs))
+	}
+	for i, err := range errs {
+		if want := Verify(signatures[i], messages[i], keyPair.PublicKey, SigCT); (err == nil) != (want == nil) {
+			t.Fatalf("Item %d: VerifyBatchSameKey = %v, Verify = %v", i, err, want)
+		}
+		if i%6 == 5 && !errors.Is(err, ErrBadSignature) {
+			t.Fatalf("Item %d: expected ErrBadSignature, got %v", i, err)
+		}
+	}
+
+	for _, err := range VerifyBatchSameKey(signatures, messages[:3], keyPair.PublicKey, SigCT) {
+		if !errors.Is(err, ErrBadArgument) {
+			t.Fatalf("Expected ErrBadArgument for mismatched lengths, got %v", err)
+		}
+	}
+	for _, err := range VerifyBatchSameKey(signatures, messages, keyPair.PrivateKey, SigCT) {
+		if !errors.Is(err, ErrBadFormat) {
+			t.Fatalf("Expected ErrBadFormat for an invalid key, got %v", err)
+		}
+	}
+}
+
+func TestVerifyBatchTamperedAlignment(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	// Enough items that every worker handles several, with tampered
+	// signatures and messages scattered among them
+	sigTypes := []int{SigCompressed, SigPadded, SigCT}
+	items := make([]VerifyItem, 60)
+	tampered := make([]bool, len(items))
+	for i := range items {
+		sigType := sigTypes[i%len(sigTypes)]
+		message := []byte(fmt.Sprintf("aligned %d", i))
+		signature, err := Sign(message, keyPair.PrivateKey, SignatureType(sigType))
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		switch {
+		case i%7 == 3:
+			signature[1] ^= 0x80
+			tampered[i] = true
+		case i%11 == 5:
+			message[0] ^= 1
+			tampered[i] = true
+		}
+		items[i] = VerifyItem{Signature: signature, Message: message, PublicKey: keyPair.PublicKey, SigType: sigType}
+	}
+
+	errs := VerifyBatch(items)
+	for i, err := range errs {
+		if tampered[i] && !errors.Is(err, ErrBadSignature) {
+			t.Fatalf("Item %d: expected ErrBadSignature, got %v", i, err)
+		}
+		if !tampered[i] && err != nil {
+			t.Fatalf("Item %d failed verification: %v", i, err)
+		}
+	}
+}
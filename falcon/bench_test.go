@@ -1,3 +1,5 @@
+//go:build cgo
+
 // internal/falcon/bench_test.go
 
 package falcon
@@ -123,6 +125,389 @@ func BenchmarkFalcon(b *testing.B) {
 	}
 }
 
+// BenchmarkEncoding measures the key and message encoding helpers. Key
+// serialization formats register their sub-benchmarks here as they are
+// added.
+func BenchmarkEncoding(b *testing.B) {
+	for _, logN := range []uint{9, 10} {
+		degree := 1 << logN
+		b.Run(fmt.Sprintf("Degree-%d", degree), func(b *testing.B) {
+			kp, err := GenerateKeyPair(logN)
+			if err != nil {
+				b.Fatalf("Failed to generate keypair: %v", err)
+			}
+
+			b.Run("Fingerprint", func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, err := Fingerprint(kp.PublicKey); err != nil {
+						b.Fatalf("Fingerprint failed: %v", err)
+					}
+				}
+			})
+
+			b.Run("MarshalPKCS8", func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, err := MarshalPKCS8PrivateKey(kp.PrivateKey); err != nil {
+						b.Fatalf("PKCS#8 marshaling failed: %v", err)
+					}
+				}
+			})
+
+			b.Run("ParsePKCS8", func(b *testing.B) {
+				der, err := MarshalPKCS8PrivateKey(kp.PrivateKey)
+				if err != nil {
+					b.Fatalf("PKCS#8 marshaling failed: %v", err)
+				}
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					if _, err := ParsePKCS8PrivateKey(der); err != nil {
+						b.Fatalf("PKCS#8 parsing failed: %v", err)
+					}
+				}
+			})
+
+			b.Run("MarshalPKIX", func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, err := MarshalPKIXPublicKey(kp.PublicKey); err != nil {
+						b.Fatalf("PKIX marshaling failed: %v", err)
+					}
+				}
+			})
+
+			b.Run("ParsePKIX", func(b *testing.B) {
+				der, err := MarshalPKIXPublicKey(kp.PublicKey)
+				if err != nil {
+					b.Fatalf("PKIX marshaling failed: %v", err)
+				}
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					if _, err := ParsePKIXPublicKey(der); err != nil {
+						b.Fatalf("PKIX parsing failed: %v", err)
+					}
+				}
+			})
+		})
+	}
+
+	b.Run("CanonicalJSON", func(b *testing.B) {
+		v := map[string]interface{}{
+			"id":     42,
+			"labels": map[string]string{"zeta": "z", "alpha": "a", "mid": "m"},
+			"counts": []int{1, 2, 3},
+		}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := canonicalEncode(v); err != nil {
+				b.Fatalf("Canonical encoding failed: %v", err)
+			}
+		}
+	})
+}
+
+// BenchmarkPrecomputedVerify compares plain Verify with verification
+// against a PrecomputedKey, which skips decoding the public key and its
+// NTT on every call
+func BenchmarkPrecomputedVerify(b *testing.B) {
+	for _, logN := range []uint{9, 10} {
+		degree := 1 << logN
+		b.Run(fmt.Sprintf("Degree-%d", degree), func(b *testing.B) {
+			kp, err := GenerateKeyPair(logN)
+			if err != nil {
+				b.Fatalf("Failed to generate keypair: %v", err)
+			}
+			message := []byte("benchmark message")
+			sig, err := Sign(message, kp.PrivateKey, SigCompressed)
+			if err != nil {
+				b.Fatalf("Failed to sign message: %v", err)
+			}
+			key, err := PrecomputePublicKey(kp.PublicKey)
+			if err != nil {
+				b.Fatalf("Failed to precompute public key: %v", err)
+			}
+
+			b.Run("Verify", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if err := Verify(sig, message, kp.PublicKey, SigCompressed); err != nil {
+						b.Fatalf("Verification failed: %v", err)
+					}
+				}
+			})
+
+			b.Run("Precomputed", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if err := key.Verify(sig, message, SigCompressed); err != nil {
+						b.Fatalf("Verification failed: %v", err)
+					}
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkExpandedSign compares Sign, which expands the private key on
+// every call, with signing through an ExpandedKey
+func BenchmarkExpandedSign(b *testing.B) {
+	for _, logN := range []uint{9, 10} {
+		degree := 1 << logN
+		b.Run(fmt.Sprintf("Degree-%d", degree), func(b *testing.B) {
+			kp, err := GenerateKeyPair(logN)
+			if err != nil {
+				b.Fatalf("Failed to generate keypair: %v", err)
+			}
+			key, err := ExpandPrivateKey(kp.PrivateKey)
+			if err != nil {
+				b.Fatalf("Failed to expand private key: %v", err)
+			}
+			message := []byte("benchmark message")
+
+			b.Run("Sign", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := Sign(message, kp.PrivateKey, SigCompressed); err != nil {
+						b.Fatalf("Signing failed: %v", err)
+					}
+				}
+			})
+
+			b.Run("SignWith", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := key.SignWith(message, SigCompressed); err != nil {
+						b.Fatalf("Signing failed: %v", err)
+					}
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkSigningTree1000 signs 1000 consecutive messages with one key
+// per iteration, with Sign and with a SigningTree expanded once up front
+func BenchmarkSigningTree1000(b *testing.B) {
+	const count = 1000
+	kp, err := GenerateKeyPair(9)
+	if err != nil {
+		b.Fatalf("Failed to generate keypair: %v", err)
+	}
+	message := []byte("benchmark message")
+
+	b.Run("Sign", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < count; j++ {
+				if _, err := Sign(message, kp.PrivateKey, SigCompressed); err != nil {
+					b.Fatalf("Signing failed: %v", err)
+				}
+			}
+		}
+	})
+
+	b.Run("SignWithTree", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tree, err := ExpandPrivateKey(kp.PrivateKey)
+			if err != nil {
+				b.Fatalf("Failed to expand private key: %v", err)
+			}
+			var rng PRNGContext
+			if err := rng.InitFromSystem(); err != nil {
+				b.Fatalf("Failed to initialize RNG: %v", err)
+			}
+			for j := 0; j < count; j++ {
+				if _, err := SignWithTree(message, tree, SigCompressed, &rng); err != nil {
+					b.Fatalf("Signing failed: %v", err)
+				}
+			}
+			tree.Destroy()
+		}
+	})
+}
+
+// BenchmarkSignBatch reports the per-signature cost of signing 100
+// messages one Sign call at a time and with one SignBatch call
+func BenchmarkSignBatch(b *testing.B) {
+	kp, err := GenerateKeyPair(9)
+	if err != nil {
+		b.Fatalf("Failed to generate keypair: %v", err)
+	}
+	messages := make([][]byte, 100)
+	for i := range messages {
+		messages[i] = []byte(fmt.Sprintf("document %d", i))
+	}
+	perSignature := func(b *testing.B) {
+		b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*len(messages)), "ns/sig")
+	}
+
+	b.Run("Sign", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, msg := range messages {
+				if _, err := Sign(msg, kp.PrivateKey, SigCompressed); err != nil {
+					b.Fatalf("Signing failed: %v", err)
+				}
+			}
+		}
+		perSignature(b)
+	})
+
+	b.Run("SignBatch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := SignBatch(messages, kp.PrivateKey, SigCompressed); err != nil {
+				b.Fatalf("Batch signing failed: %v", err)
+			}
+		}
+		perSignature(b)
+	})
+}
+
+// BenchmarkVerifyBatch compares verifying 256 Falcon-512 signatures one
+// after the other with the parallel batch functions
+func BenchmarkVerifyBatch(b *testing.B) {
+	kp, err := GenerateKeyPair(9)
+	if err != nil {
+		b.Fatalf("Failed to generate keypair: %v", err)
+	}
+	items := make([]VerifyItem, 256)
+	signatures := make([][]byte, len(items))
+	messages := make([][]byte, len(items))
+	for i := range items {
+		messages[i] = []byte(fmt.Sprintf("batch item %d", i))
+		if signatures[i], err = Sign(messages[i], kp.PrivateKey, SigCompressed); err != nil {
+			b.Fatalf("Failed to sign message: %v", err)
+		}
+		items[i] = VerifyItem{Signature: signatures[i], Message: messages[i], PublicKey: kp.PublicKey, SigType: SigCompressed}
+	}
+
+	b.Run("Sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, item := range items {
+				if err := Verify(item.Signature, item.Message, item.PublicKey, SignatureType(item.SigType)); err != nil {
+					b.Fatalf("Verification failed: %v", err)
+				}
+			}
+		}
+	})
+
+	b.Run("VerifyBatch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, err := range VerifyBatch(items) {
+				if err != nil {
+					b.Fatalf("Verification failed: %v", err)
+				}
+			}
+		}
+	})
+
+	b.Run("VerifyBatchSameKey", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, err := range VerifyBatchSameKey(signatures, messages, kp.PublicKey, SigCompressed) {
+				if err != nil {
+					b.Fatalf("Verification failed: %v", err)
+				}
+			}
+		}
+	})
+}
+
+// BenchmarkSignerPool compares allocations of Sign and SignerPool.Sign
+// with 32 goroutines per GOMAXPROCS signing concurrently
+func BenchmarkSignerPool(b *testing.B) {
+	kp, err := GenerateKeyPair(9)
+	if err != nil {
+		b.Fatalf("Failed to generate keypair: %v", err)
+	}
+	message := []byte("benchmark message")
+	pool := NewSignerPool(9)
+
+	b.Run("Sign", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetParallelism(32)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if _, err := Sign(message, kp.PrivateKey, SigCompressed); err != nil {
+					b.Errorf("Signing failed: %v", err)
+					return
+				}
+			}
+		})
+	})
+
+	b.Run("SignerPool", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetParallelism(32)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if _, err := pool.Sign(message, kp.PrivateKey, SigCompressed); err != nil {
+					b.Errorf("Signing failed: %v", err)
+					return
+				}
+			}
+		})
+	})
+}
+
+// BenchmarkScratchAllocs reports the allocations of the one-shot API,
+// whose scratch buffers come from per-degree pools; run with -benchmem
+func BenchmarkScratchAllocs(b *testing.B) {
+	kp, err := GenerateKeyPair(9)
+	if err != nil {
+		b.Fatalf("Failed to generate keypair: %v", err)
+	}
+	message := []byte("benchmark message")
+	sig, err := Sign(message, kp.PrivateKey, SigCompressed)
+	if err != nil {
+		b.Fatalf("Failed to sign message: %v", err)
+	}
+
+	b.Run("Sign", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := Sign(message, kp.PrivateKey, SigCompressed); err != nil {
+				b.Fatalf("Signing failed: %v", err)
+			}
+		}
+	})
+
+	b.Run("Verify", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := Verify(sig, message, kp.PublicKey, SigCompressed); err != nil {
+				b.Fatalf("Verification failed: %v", err)
+			}
+		}
+	})
+}
+
+// BenchmarkSignWithRNG compares seeding a fresh PRNG from the system
+// for every signature with reusing one caller-managed PRNG
+func BenchmarkSignWithRNG(b *testing.B) {
+	kp, err := GenerateKeyPair(9)
+	if err != nil {
+		b.Fatalf("Failed to generate keypair: %v", err)
+	}
+	message := []byte("benchmark message")
+
+	b.Run("FreshRNG", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := Sign(message, kp.PrivateKey, SigCompressed); err != nil {
+				b.Fatalf("Signing failed: %v", err)
+			}
+		}
+	})
+
+	b.Run("ReusedRNG", func(b *testing.B) {
+		rng := &PRNGContext{}
+		if err := rng.InitFromSystem(); err != nil {
+			b.Fatalf("Failed to seed PRNG: %v", err)
+		}
+		for i := 0; i < b.N; i++ {
+			if _, err := SignWithRNG(message, kp.PrivateKey, SigCompressed, rng); err != nil {
+				b.Fatalf("Signing failed: %v", err)
+			}
+		}
+	})
+}
+
 // Helper function to print results similar to C implementation
 func PrintBenchmarkResults(result testing.BenchmarkResult, name string) {
 	nsPerOp := result.NsPerOp()
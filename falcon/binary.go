@@ -0,0 +1,75 @@
+package falcon
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// keyPairMagic starts every binary-encoded KeyPair
+var keyPairMagic = [4]byte{'F', 'L', 'K', 'P'}
+
+// keyPairBinaryHeader is the size of the magic, logN and private key
+// length fields
+const keyPairBinaryHeader = len(keyPairMagic) + 1 + 2
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is a
+// 4-byte magic "FLKP", the logN byte, the private key length as a
+// big-endian uint16, the private key and the public key. Unlike
+// MarshalJSON it includes the private key, so the output must be
+// protected like the key itself.
+func (kp *KeyPair) MarshalBinary() ([]byte, error) {
+	logN, err := checkPrivateKey(kp.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	pubLogN, err := checkPublicKey(kp.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	if pubLogN != logN {
+		return nil, ErrKeyMismatch
+	}
+
+	out := make([]byte, 0, keyPairBinaryHeader+len(kp.PrivateKey)+len(kp.PublicKey))
+	out = append(out, keyPairMagic[:]...)
+	out = append(out, byte(logN))
+	out = binary.BigEndian.AppendUint16(out, uint16(len(kp.PrivateKey)))
+	out = append(out, kp.PrivateKey...)
+	return append(out, kp.PublicKey...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, decoding the
+// output of MarshalBinary into kp. The keys are copied out of data.
+// Malformed input yields errors matching ErrBadFormat and leaves kp
+// unchanged.
+func (kp *KeyPair) UnmarshalBinary(data []byte) error {
+	if len(data) < keyPairBinaryHeader {
+		return fmt.Errorf("%w: key pair encoding is %d bytes", ErrBadLength, len(data))
+	}
+	if [4]byte(data[:4]) != keyPairMagic {
+		return fmt.Errorf("%w: not a binary key pair", ErrBadHeader)
+	}
+	logN := uint(data[4])
+	if logN < 1 || logN > 10 {
+		return fmt.Errorf("%w: logN %d", ErrUnsupportedDegree, logN)
+	}
+	privLen := int(binary.BigEndian.Uint16(data[5:7]))
+	body := data[keyPairBinaryHeader:]
+	if privLen != privateKeySize(logN) || len(body) != privLen+publicKeySize(logN) {
+		return fmt.Errorf("%w: key lengths do not match logN %d", ErrBadLength, logN)
+	}
+
+	priv, pub := body[:privLen], body[privLen:]
+	if got, err := checkPrivateKey(priv); err != nil || got != logN {
+		return fmt.Errorf("invalid private key: %w", ErrBadHeader)
+	}
+	if got, err := checkPublicKey(pub); err != nil || got != logN {
+		return fmt.Errorf("invalid public key: %w", ErrBadHeader)
+	}
+
+	*kp = KeyPair{
+		PublicKey:  append(PublicKey{}, pub...),
+		PrivateKey: append(PrivateKey{}, priv...),
+	}
+	return nil
+}
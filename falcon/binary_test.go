@@ -0,0 +1,135 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"testing"
+)
+
+func TestKeyPairBinary(t *testing.T) {
+	for _, logN := range []uint{1, 9, 10} {
+		keyPair, err := GenerateKeyPair(logN)
+		if err != nil {
+			t.Fatalf("Failed to generate key pair: %v", err)
+		}
+		data, err := keyPair.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Failed to marshal key pair: %v", err)
+		}
+		if want := 7 + len(keyPair.PrivateKey) + len(keyPair.PublicKey); len(data) != want {
+			t.Fatalf("Encoding is %d bytes, want %d", len(data), want)
+		}
+
+		var decoded KeyPair
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("Failed to unmarshal key pair: %v", err)
+		}
+		if !decoded.PublicKey.Equal(keyPair.PublicKey) || !decoded.PrivateKey.Equal(keyPair.PrivateKey) {
+			t.Fatalf("logN %d: key pair changed in round trip", logN)
+		}
+		if got, _ := decoded.LogN(); got != int(logN) {
+			t.Fatalf("Decoded LogN() = %d, want %d", got, logN)
+		}
+		data[len(data)-1] ^= 1
+		if bytes.Equal(decoded.PublicKey, data[len(data)-len(decoded.PublicKey):]) {
+			t.Fatal("Decoded key pair shares memory with its input")
+		}
+	}
+}
+
+func TestKeyPairGob(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(keyPair); err != nil {
+		t.Fatalf("Failed to gob-encode key pair: %v", err)
+	}
+	var decoded KeyPair
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("Failed to gob-decode key pair: %v", err)
+	}
+	if !decoded.PrivateKey.Equal(keyPair.PrivateKey) || !decoded.PublicKey.Equal(keyPair.PublicKey) {
+		t.Fatal("Key pair changed in gob round trip")
+	}
+}
+
+func TestKeyPairBinaryMalformed(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	data, err := keyPair.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Failed to marshal key pair: %v", err)
+	}
+	modified := func(i int, b byte) []byte {
+		c := append([]byte{}, data...)
+		c[i] = b
+		return c
+	}
+
+	for name, bad := range map[string][]byte{
+		"Empty":         nil,
+		"HeaderOnly":    data[:7],
+		"BadMagic":      modified(0, 'X'),
+		"DegreeZero":    modified(4, 0),
+		"DegreeEleven":  modified(4, 11),
+		"WrongDegree":   modified(4, 10),
+		"WrongLength":   modified(6, data[6]+1),
+		"Truncated":     data[:len(data)-1],
+		"Trailing":      append(append([]byte{}, data...), 0),
+		"BadPrivHeader": modified(7, 0x09),
+		"BadPubHeader":  modified(7+len(keyPair.PrivateKey), 0x59),
+	} {
+		kp := KeyPair{PublicKey: []byte("unchanged")}
+		if err := kp.UnmarshalBinary(bad); !errors.Is(err, ErrBadFormat) {
+			t.Errorf("%s: expected ErrBadFormat, got %v", name, err)
+		}
+		if string(kp.PublicKey) != "unchanged" {
+			t.Errorf("%s: failed unmarshal modified the key pair", name)
+		}
+	}
+
+	other, err := GenerateKeyPair(10)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	mixed := &KeyPair{PublicKey: other.PublicKey, PrivateKey: keyPair.PrivateKey}
+	if _, err := mixed.MarshalBinary(); !errors.Is(err, ErrKeyMismatch) {
+		t.Fatalf("Expected ErrKeyMismatch, got %v", err)
+	}
+}
+
+func FuzzKeyPairBinary(f *testing.F) {
+	for _, logN := range []uint{1, 4} {
+		keyPair, err := GenerateKeyPair(logN)
+		if err != nil {
+			f.Fatalf("Failed to generate key pair: %v", err)
+		}
+		data, err := keyPair.MarshalBinary()
+		if err != nil {
+			f.Fatalf("Failed to marshal key pair: %v", err)
+		}
+		f.Add(data)
+	}
+	f.Add([]byte("FLKP"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var kp KeyPair
+		if err := kp.UnmarshalBinary(data); err != nil {
+			return
+		}
+		again, err := kp.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Failed to re-marshal a decoded key pair: %v", err)
+		}
+		if !bytes.Equal(again, data) {
+			t.Fatal("MarshalBinary(UnmarshalBinary(data)) differs from data")
+		}
+	})
+}
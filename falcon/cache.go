@@ -0,0 +1,78 @@
+package falcon
+
+import (
+	"container/list"
+	"encoding/binary"
+	"sync"
+)
+
+// cacheKeySize is the digest length used to key VerifyCache entries
+const cacheKeySize = 32
+
+// VerifyCache memoizes successful signature verifications, keyed by a
+// SHAKE256 digest of the signature, message, public key and signature
+// type. Only successes are remembered: failed or malformed inputs are
+// verified again on every call. It is safe for concurrent use.
+type VerifyCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // front = most recently used
+	entries map[string]*list.Element
+	hits    uint64
+}
+
+// NewVerifyCache creates a cache holding up to size successful results,
+// evicting the least recently used entry when full
+func NewVerifyCache(size int) *VerifyCache {
+	return &VerifyCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Verify returns nil if the signature is valid, consulting the cache
+// before running the full verification
+func (c *VerifyCache) Verify(signature, message, publicKey []byte, sigType int) error {
+	if c.size <= 0 {
+		return newError(ErrBadArg, "cache size must be positive")
+	}
+
+	// The full sigType is keyed, so that 257 cannot hit an entry for 1
+	typ := binary.BigEndian.AppendUint64(nil, uint64(sigType))
+	key := string(shake256Framed(cacheKeySize, signature, message, publicKey, typ))
+
+	c.mu.Lock()
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+		c.hits++
+		c.mu.Unlock()
+		return nil
+	}
+	c.mu.Unlock()
+
+	if err := Verify(signature, message, publicKey, SignatureType(sigType)); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+		return nil
+	}
+	c.entries[key] = c.order.PushFront(key)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(string))
+	}
+	return nil
+}
+
+// Len returns the number of cached results
+func (c *VerifyCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,91 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestVerifyCache(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	message := []byte("cached message")
+	signature, err := Sign(message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	cache := NewVerifyCache(2)
+	if err := cache.Verify(signature, message, keyPair.PublicKey, SigCompressed); err != nil {
+		t.Fatalf("Signature verification failed: %v", err)
+	}
+	if cache.hits != 0 {
+		t.Fatalf("Unexpected cache hit on first verify")
+	}
+	if err := cache.Verify(signature, message, keyPair.PublicKey, SigCompressed); err != nil {
+		t.Fatalf("Cached verification failed: %v", err)
+	}
+	if cache.hits != 1 {
+		t.Fatalf("Expected a cache hit on second verify, got %d hits", cache.hits)
+	}
+
+	// A sigType that only matches in its low byte must not hit the entry
+	if err := cache.Verify(signature, message, keyPair.PublicKey, 256+SigCompressed); !errors.Is(err, ErrBadArgument) {
+		t.Fatalf("Expected ErrBadArgument for sigType 257, got %v", err)
+	}
+
+	// Failures must never be cached
+	tampered := append([]byte{}, message...)
+	tampered[0] ^= 1
+	for i := 0; i < 2; i++ {
+		if err := cache.Verify(signature, tampered, keyPair.PublicKey, SigCompressed); err == nil {
+			t.Fatal("Verification succeeded for modified message")
+		}
+	}
+	if err := cache.Verify(signature[:10], message, keyPair.PublicKey, SigCompressed); err == nil {
+		t.Fatal("Verification succeeded for malformed signature")
+	}
+	if cache.Len() != 1 {
+		t.Fatalf("Expected 1 cached entry, got %d", cache.Len())
+	}
+
+	// Filling past capacity evicts the least recently used entry
+	for i := 0; i < 3; i++ {
+		msg := []byte(fmt.Sprintf("eviction %d", i))
+		sig, err := Sign(msg, keyPair.PrivateKey, SigCompressed)
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		if err := cache.Verify(sig, msg, keyPair.PublicKey, SigCompressed); err != nil {
+			t.Fatalf("Signature verification failed: %v", err)
+		}
+	}
+	if cache.Len() != 2 {
+		t.Fatalf("Expected cache to be capped at 2 entries, got %d", cache.Len())
+	}
+	hits := cache.hits
+	if err := cache.Verify(signature, message, keyPair.PublicKey, SigCompressed); err != nil {
+		t.Fatalf("Signature verification failed: %v", err)
+	}
+	if cache.hits != hits {
+		t.Fatal("Evicted entry was still served from the cache")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := cache.Verify(signature, message, keyPair.PublicKey, SigCompressed); err != nil {
+				t.Errorf("Concurrent verification failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,23 @@
+package falcon
+
+import "crypto/subtle"
+
+// PrivateKeyEqual reports whether two encoded private keys are identical.
+// Keys of equal length are compared in constant time; the comparison
+// never stops at the first differing byte, so it is safe to use on
+// secret material where bytes.Equal is not.
+func PrivateKeyEqual(a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
+
+// SignatureEqual reports whether two encoded signatures are identical,
+// for replay detection and similar checks. Signatures are not secret,
+// but comparing them in constant time like PrivateKeyEqual means
+// callers never have to decide when bytes.Equal is safe. Signatures of
+// different lengths are unequal.
+func SignatureEqual(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
@@ -0,0 +1,54 @@
+//go:build cgo
+
+package falcon
+
+import "testing"
+
+func TestPrivateKeyEqual(t *testing.T) {
+	a, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	b, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	if !PrivateKeyEqual(a.PrivateKey, append([]byte{}, a.PrivateKey...)) {
+		t.Error("Identical private keys reported as different")
+	}
+	if PrivateKeyEqual(a.PrivateKey, b.PrivateKey) {
+		t.Error("Different private keys of the same length reported as equal")
+	}
+	if PrivateKeyEqual(a.PrivateKey, a.PrivateKey[:len(a.PrivateKey)-1]) {
+		t.Error("Private keys of different lengths reported as equal")
+	}
+}
+
+func TestSignatureEqual(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	a, err := Sign([]byte("replay"), keyPair.PrivateKey, SigPadded)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	b, err := Sign([]byte("replay"), keyPair.PrivateKey, SigPadded)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	if !SignatureEqual(a, append([]byte{}, a...)) {
+		t.Error("Identical signatures reported as different")
+	}
+	if SignatureEqual(a, b) {
+		t.Error("Different signatures of the same length reported as equal")
+	}
+	if SignatureEqual(a, a[:len(a)-1]) {
+		t.Error("Signatures of different lengths reported as equal")
+	}
+	if !SignatureEqual(nil, []byte{}) {
+		t.Error("Empty signatures reported as different")
+	}
+}
@@ -0,0 +1,40 @@
+package falcon
+
+import "sync"
+
+// limiter bounds the number of concurrent cgo operations
+var limiter struct {
+	mu  sync.RWMutex
+	sem chan struct{}
+}
+
+// SetMaxConcurrency limits how many key generation, signing and
+// verification operations may run in C at once, bounding the peak memory
+// used by their scratch buffers. Callers beyond the limit block until a
+// slot frees up. Zero (the default) or a negative value removes the
+// limit. Operations already waiting or running keep the limit that was
+// in effect when they started.
+func SetMaxConcurrency(n int) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	if n <= 0 {
+		limiter.sem = nil
+		return
+	}
+	limiter.sem = make(chan struct{}, n)
+}
+
+// acquire blocks until an operation slot is available and returns the
+// function that releases it
+func acquire() func() {
+	limiter.mu.RLock()
+	sem := limiter.sem
+	limiter.mu.RUnlock()
+
+	if sem == nil {
+		return func() {}
+	}
+	sem <- struct{}{}
+	return func() { <-sem }
+}
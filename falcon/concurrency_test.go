@@ -0,0 +1,79 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSetMaxConcurrency(t *testing.T) {
+	SetMaxConcurrency(1)
+	defer SetMaxConcurrency(0)
+
+	var inFlight, maxInFlight int32
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release := acquire()
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				m := atomic.LoadInt32(&maxInFlight)
+				if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			release()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight != 1 {
+		t.Fatalf("Expected operations to be serialized, saw %d in flight", maxInFlight)
+	}
+
+	// Real operations still complete under the limit
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	message := []byte("limited")
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			signature, err := Sign(message, keyPair.PrivateKey, SigCompressed)
+			if err != nil {
+				t.Errorf("Failed to sign message: %v", err)
+				return
+			}
+			if err := Verify(signature, message, keyPair.PublicKey, SigCompressed); err != nil {
+				t.Errorf("Signature verification failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Deriving a public key waits for a slot like the other C calls
+	release := acquire()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := DerivePublicKey(keyPair.PrivateKey); err != nil {
+			t.Errorf("Failed to derive public key: %v", err)
+		}
+	}()
+	select {
+	case <-done:
+		t.Fatal("DerivePublicKey ran while the only slot was held")
+	case <-time.After(20 * time.Millisecond):
+	}
+	release()
+	<-done
+}
@@ -0,0 +1,43 @@
+package falcon
+
+import (
+	"context"
+	"fmt"
+)
+
+// GenerateKeyPairContext is GenerateKeyPair with cancellation. The C key
+// generation cannot be interrupted, so ctx is checked before it starts
+// and again when it returns; a pair generated after ctx was cancelled is
+// wiped and discarded. Cancellation errors wrap ctx.Err().
+func GenerateKeyPairContext(ctx context.Context, logN uint) (*KeyPair, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("key generation cancelled: %w", err)
+	}
+	kp, err := GenerateKeyPair(logN)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		kp.Zero()
+		return nil, fmt.Errorf("key generation cancelled: %w", err)
+	}
+	return kp, nil
+}
+
+// SignContext is Sign with cancellation. As with GenerateKeyPairContext,
+// ctx is checked before and after the uninterruptible C call, and a
+// signature completed after cancellation is discarded. Cancellation
+// errors wrap ctx.Err().
+func SignContext(ctx context.Context, message, privateKey []byte, sigType int) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("signing cancelled: %w", err)
+	}
+	signature, err := Sign(message, privateKey, SignatureType(sigType))
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("signing cancelled: %w", err)
+	}
+	return signature, nil
+}
@@ -0,0 +1,47 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestContextVariants(t *testing.T) {
+	keyPair, err := GenerateKeyPairContext(context.Background(), 9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	message := []byte("request body")
+	signature, err := SignContext(context.Background(), message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	if err := Verify(signature, message, keyPair.PublicKey, SigCompressed); err != nil {
+		t.Fatalf("Signature failed verification: %v", err)
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := GenerateKeyPairContext(cancelled, 9); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled from key generation, got %v", err)
+	}
+	if _, err := SignContext(cancelled, message, keyPair.PrivateKey, SigCompressed); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled from signing, got %v", err)
+	}
+
+	// An expired deadline is reported as such
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Millisecond))
+	defer cancel()
+	time.Sleep(2 * time.Millisecond)
+	if _, err := SignContext(expired, message, keyPair.PrivateKey, SigCompressed); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// Argument errors are still reported for a live context
+	if _, err := GenerateKeyPairContext(context.Background(), 0); !errors.Is(err, ErrBadArgument) {
+		t.Fatalf("Expected ErrBadArgument, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package falcon
+
+import "fmt"
+
+// ErrNotRepresentable is returned by ConvertSignature when the signature
+// value does not fit the target format, for example a compressed
+// encoding longer than the padded size
+var ErrNotRepresentable = newError(ErrSize, "signature cannot be represented in the target format")
+
+// ConvertSignature re-encodes a Falcon-2^logN signature from one format
+// (SigCompressed, SigPadded or SigCT) to another without the private
+// key: the signature value is decoded and encoded again, keeping the
+// nonce. Compressed and CT signatures hash the message to the same
+// point, so the result verifies wherever the input did. The input must
+// be a well-formed signature of type from; a value the target encoding
+// cannot hold yields ErrNotRepresentable.
+func ConvertSignature(signature []byte, from, to int, logN int) ([]byte, error) {
+	if logN < 1 || logN > 10 {
+		return nil, errInvalidLogN
+	}
+	if from == 0 {
+		return nil, errInvalidSigType
+	}
+	sigLogN, err := checkSignature(signature, from)
+	if err != nil {
+		return nil, fmt.Errorf("malformed signature: %w", err)
+	}
+	if sigLogN != uint(logN) {
+		return nil, fmt.Errorf("%w: Falcon-%d signature, want Falcon-%d", ErrUnsupportedDegree, 1<<sigLogN, 1<<logN)
+	}
+	size, err := sigBufferSize(sigLogN, to)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCodec(); err != nil {
+		return nil, err
+	}
+
+	body := signature[1+nonceSize:]
+	value, n := decodeSigValue(body, sigLogN, from == SigCT)
+	if n == 0 {
+		return nil, fmt.Errorf("%w: undecodable signature body", ErrBadFormat)
+	}
+	for _, b := range body[n:] {
+		// Only the padded format may carry bytes past the encoding, and
+		// they must be zero
+		if from != SigPadded || b != 0 {
+			return nil, fmt.Errorf("%w: trailing data after signature value", ErrBadFormat)
+		}
+	}
+
+	out := make([]byte, size)
+	out[0] = headerSigCompressed | byte(sigLogN)
+	if to == SigCT {
+		out[0] = headerSigCT | byte(sigLogN)
+	}
+	copy(out[1:], signature[1:1+nonceSize])
+
+	n = encodeSigValue(out[1+nonceSize:], value, sigLogN, to == SigCT)
+	if n == 0 {
+		return nil, ErrNotRepresentable
+	}
+	if to == SigCompressed {
+		out = out[:1+nonceSize+n]
+	}
+	return out, nil
+}
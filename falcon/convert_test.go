@@ -0,0 +1,83 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestConvertSignature(t *testing.T) {
+	sigTypes := []int{SigCompressed, SigPadded, SigCT}
+	for _, logN := range []uint{9, 10} {
+		keyPair, err := GenerateKeyPair(logN)
+		if err != nil {
+			t.Fatalf("Failed to generate key pair: %v", err)
+		}
+		message := []byte("convert me")
+
+		for _, from := range sigTypes {
+			signature, err := Sign(message, keyPair.PrivateKey, SignatureType(from))
+			if err != nil {
+				t.Fatalf("Failed to sign message: %v", err)
+			}
+			for _, to := range sigTypes {
+				converted, err := ConvertSignature(signature, from, to, int(logN))
+				if err != nil {
+					t.Fatalf("logN %d: %d -> %d failed: %v", logN, from, to, err)
+				}
+				if err := Verify(converted, message, keyPair.PublicKey, SignatureType(to)); err != nil {
+					t.Fatalf("logN %d: %d -> %d does not verify: %v", logN, from, to, err)
+				}
+				back, err := ConvertSignature(converted, to, from, int(logN))
+				if err != nil {
+					t.Fatalf("logN %d: %d -> %d -> %d failed: %v", logN, from, to, from, err)
+				}
+				if !bytes.Equal(back, signature) {
+					t.Fatalf("logN %d: %d -> %d -> %d changed the signature", logN, from, to, from)
+				}
+			}
+		}
+	}
+}
+
+func TestConvertSignatureErrors(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	signature, err := Sign([]byte("m"), keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	if _, err := ConvertSignature(signature, SigCompressed, SigPadded, 10); !errors.Is(err, ErrUnsupportedDegree) {
+		t.Fatalf("Expected ErrUnsupportedDegree, got %v", err)
+	}
+	if _, err := ConvertSignature(signature, SigCT, SigPadded, 9); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("Expected ErrBadFormat for the wrong source type, got %v", err)
+	}
+	if _, err := ConvertSignature(signature, SigCompressed, 9, 9); !errors.Is(err, ErrBadArgument) {
+		t.Fatalf("Expected ErrBadArgument for an invalid target type, got %v", err)
+	}
+	if _, err := ConvertSignature(signature[:len(signature)-1], SigCompressed, SigCT, 9); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("Expected ErrBadFormat for a truncated signature, got %v", err)
+	}
+
+	// A compressed encoding longer than the padded size cannot be padded
+	value := make([]int16, 512)
+	for i := range value {
+		value[i] = 200
+	}
+	long := make([]byte, sigCompressedMaxSize(9))
+	long[0] = headerSigCompressed | 9
+	n := encodeSigValue(long[1+nonceSize:], value, 9, false)
+	if n == 0 {
+		t.Fatal("Failed to build an oversized compressed signature")
+	}
+	long = long[:1+nonceSize+n]
+	if _, err := ConvertSignature(long, SigCompressed, SigPadded, 9); !errors.Is(err, ErrNotRepresentable) {
+		t.Fatalf("Expected ErrNotRepresentable, got %v", err)
+	}
+}
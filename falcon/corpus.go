@@ -0,0 +1,50 @@
+package falcon
+
+import "fmt"
+
+// corpusMessageSize is the length of each message in a generated corpus
+const corpusMessageSize = 32
+
+// MsgSig is a message together with its signature
+type MsgSig struct {
+	Message   []byte
+	Signature []byte
+}
+
+// GenerateCorpus deterministically derives one key pair and n
+// (message, signature) pairs from seed, for reproducible stress and
+// fuzzing corpora. The same seed, degree, count and signature type always
+// produce byte-identical output. The seed must be at least 32 bytes;
+// never use corpus keys for anything but testing.
+func GenerateCorpus(seed []byte, logN uint, n int, sigType int) ([]byte, []MsgSig, error) {
+	if logN < 1 || logN > 10 {
+		return nil, nil, errInvalidLogN
+	}
+	if n < 0 {
+		return nil, nil, newError(ErrBadArg, "corpus size must not be negative")
+	}
+
+	rng, err := newSeededPRNG(seed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kp, err := keygen(rng, logN)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate corpus key: %w", err)
+	}
+
+	pairs := make([]MsgSig, n)
+	for i := range pairs {
+		msg := make([]byte, corpusMessageSize)
+		rng.Extract(msg)
+
+		sig, err := signWithPRNG(rng, msg, kp.PrivateKey, sigType)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to sign corpus message %d: %w", i, err)
+		}
+		pairs[i] = MsgSig{Message: msg, Signature: sig}
+	}
+
+	return kp.PublicKey, pairs, nil
+}
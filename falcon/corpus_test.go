@@ -0,0 +1,52 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestGenerateCorpus(t *testing.T) {
+	seed := bytes.Repeat([]byte{0x5a}, 32)
+
+	pub1, pairs1, err := GenerateCorpus(seed, 9, 8, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to generate corpus: %v", err)
+	}
+	pub2, pairs2, err := GenerateCorpus(seed, 9, 8, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to generate corpus: %v", err)
+	}
+
+	if !bytes.Equal(pub1, pub2) {
+		t.Fatal("Corpus public keys differ for the same seed")
+	}
+	if len(pairs1) != 8 || len(pairs2) != 8 {
+		t.Fatalf("Unexpected corpus sizes: %d, %d", len(pairs1), len(pairs2))
+	}
+	for i := range pairs1 {
+		if !bytes.Equal(pairs1[i].Message, pairs2[i].Message) {
+			t.Fatalf("Message %d differs between corpora", i)
+		}
+		if !bytes.Equal(pairs1[i].Signature, pairs2[i].Signature) {
+			t.Fatalf("Signature %d differs between corpora", i)
+		}
+		if err := Verify(pairs1[i].Signature, pairs1[i].Message, pub1, SigCompressed); err != nil {
+			t.Fatalf("Corpus signature %d failed verification: %v", i, err)
+		}
+	}
+
+	other := bytes.Repeat([]byte{0xa5}, 32)
+	pub3, _, err := GenerateCorpus(other, 9, 1, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to generate corpus: %v", err)
+	}
+	if bytes.Equal(pub1, pub3) {
+		t.Fatal("Different seeds produced the same corpus key")
+	}
+
+	if _, _, err := GenerateCorpus(seed[:16], 9, 1, SigCompressed); err == nil {
+		t.Fatal("Expected error for short seed")
+	}
+}
@@ -0,0 +1,120 @@
+package falcon
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// debugDumpHeader is the first line of every DebugDump
+const debugDumpHeader = "falcon-go debug dump v1"
+
+// looksLikePrivateKey reports whether b has the header and length of an
+// encoded private key
+func looksLikePrivateKey(b []byte) bool {
+	if len(b) == 0 || b[0]&0xF0 != headerPrivateKey {
+		return false
+	}
+	logN, err := headerLogN(b[0])
+	return err == nil && len(b) == privateKeySize(logN)
+}
+
+// DebugDump renders the inputs of a verification, along with decoded
+// metadata and the verification result, as text suitable for pasting
+// into a bug report. ParseDebugDump recovers the inputs. Signatures,
+// messages and public keys are public, so nothing is redacted; a value
+// in any position that looks like a private key, as when arguments are
+// swapped, is refused and left out of the dump.
+func DebugDump(signature, message, publicKey []byte, sigType int) string {
+	var b strings.Builder
+	fmt.Fprintln(&b, debugDumpHeader)
+	fmt.Fprintf(&b, "sig-type: %d (%s)\n", sigType, SignatureType(sigType))
+
+	if looksLikePrivateKey(publicKey) || looksLikePrivateKey(signature) || looksLikePrivateKey(message) {
+		fmt.Fprintln(&b, "refused: input looks like a private key")
+		return b.String()
+	}
+
+	if logN, err := checkPublicKey(publicKey); err != nil {
+		fmt.Fprintf(&b, "pub-error: %v\n", err)
+	} else {
+		fmt.Fprintf(&b, "pub-logn: %d\n", logN)
+	}
+	if detected, err := DetectSigType(signature); err != nil {
+		fmt.Fprintf(&b, "sig-error: %v\n", err)
+	} else {
+		fmt.Fprintf(&b, "sig-logn: %d\n", signature[0]&0x0F)
+		fmt.Fprintf(&b, "sig-format: %s (header 0x%02x)\n", SignatureType(detected), signature[0])
+	}
+	fmt.Fprintf(&b, "sig-len: %d\n", len(signature))
+	fmt.Fprintf(&b, "msg-len: %d\n", len(message))
+	fmt.Fprintf(&b, "pub-len: %d\n", len(publicKey))
+	if err := Verify(signature, message, publicKey, SignatureType(sigType)); err != nil {
+		fmt.Fprintf(&b, "verify: %v\n", err)
+	} else {
+		fmt.Fprintln(&b, "verify: ok")
+	}
+
+	fmt.Fprintf(&b, "sig: %s\n", base64.StdEncoding.EncodeToString(signature))
+	fmt.Fprintf(&b, "msg: %s\n", base64.StdEncoding.EncodeToString(message))
+	fmt.Fprintf(&b, "pub: %s\n", base64.StdEncoding.EncodeToString(publicKey))
+	return b.String()
+}
+
+// ParseDebugDump recovers the verification inputs from a DebugDump.
+// The metadata lines are informational and ignored. Dumps containing a
+// value that looks like a private key are rejected.
+func ParseDebugDump(dump string) (signature, message, publicKey []byte, sigType int, err error) {
+	fields := map[string]string{}
+	sc := bufio.NewScanner(strings.NewReader(dump))
+	sc.Buffer(nil, 1<<24)
+	first := true
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		if first {
+			if line != debugDumpHeader {
+				return nil, nil, nil, 0, fmt.Errorf("%w: not a debug dump", ErrBadFormat)
+			}
+			first = false
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, nil, nil, 0, fmt.Errorf("%w: malformed line %q", ErrBadFormat, line)
+		}
+		fields[key] = strings.TrimSpace(value)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, nil, nil, 0, err
+	}
+	if _, ok := fields["refused"]; ok {
+		return nil, nil, nil, 0, errors.New("dump was refused when created")
+	}
+
+	typeField, _, _ := strings.Cut(fields["sig-type"], " ")
+	if sigType, err = strconv.Atoi(typeField); err != nil {
+		return nil, nil, nil, 0, fmt.Errorf("%w: bad sig-type: %v", ErrBadFormat, err)
+	}
+
+	decoded := make(map[string][]byte, 3)
+	for _, name := range []string{"sig", "msg", "pub"} {
+		value, ok := fields[name]
+		if !ok {
+			return nil, nil, nil, 0, fmt.Errorf("%w: missing %s", ErrBadFormat, name)
+		}
+		if decoded[name], err = base64.StdEncoding.DecodeString(value); err != nil {
+			return nil, nil, nil, 0, fmt.Errorf("%w: bad %s: %v", ErrBadFormat, name, err)
+		}
+	}
+
+	if looksLikePrivateKey(decoded["pub"]) || looksLikePrivateKey(decoded["sig"]) || looksLikePrivateKey(decoded["msg"]) {
+		return nil, nil, nil, 0, errors.New("dump contains what looks like a private key")
+	}
+	return decoded["sig"], decoded["msg"], decoded["pub"], sigType, nil
+}
@@ -0,0 +1,77 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestDebugDump(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	message := []byte("repro case")
+	signature, err := Sign(message, keyPair.PrivateKey, SigCT)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	dump := DebugDump(signature, message, keyPair.PublicKey, SigCT)
+	for _, want := range []string{"sig-type: 3 (constant-time)", "pub-logn: 9", "sig-format: constant-time", "verify: ok"} {
+		if !strings.Contains(dump, want) {
+			t.Fatalf("Dump is missing %q:\n%s", want, dump)
+		}
+	}
+
+	sig, msg, pub, sigType, err := ParseDebugDump(dump)
+	if err != nil {
+		t.Fatalf("Failed to parse dump: %v", err)
+	}
+	if !bytes.Equal(sig, signature) || !bytes.Equal(msg, message) || !bytes.Equal(pub, keyPair.PublicKey) || sigType != SigCT {
+		t.Fatal("Parsed dump differs from the original inputs")
+	}
+	if err := Verify(sig, msg, pub, SignatureType(sigType)); err != nil {
+		t.Fatalf("Parsed inputs failed verification: %v", err)
+	}
+
+	// A failing case is dumped with its error
+	dump = DebugDump(signature, []byte("other"), keyPair.PublicKey, SigCT)
+	if strings.Contains(dump, "verify: ok") {
+		t.Fatalf("Dump reports success for a bad signature:\n%s", dump)
+	}
+
+	// A private key is never written into a dump
+	encodedPriv := base64.StdEncoding.EncodeToString(keyPair.PrivateKey)
+	dump = DebugDump(signature, message, keyPair.PrivateKey, SigCT)
+	if strings.Contains(dump, encodedPriv) || !strings.Contains(dump, "refused") {
+		t.Fatalf("Dump accepted a private key:\n%s", dump)
+	}
+	if _, _, _, _, err := ParseDebugDump(dump); err == nil {
+		t.Fatal("Expected error parsing a refused dump")
+	}
+	dump = DebugDump(signature, keyPair.PrivateKey, keyPair.PublicKey, SigCT)
+	if strings.Contains(dump, encodedPriv) || !strings.Contains(dump, "refused") {
+		t.Fatalf("Dump accepted a private key as the message:\n%s", dump)
+	}
+
+	// Nor accepted from a hand-edited one
+	edited := strings.Replace(DebugDump(signature, message, keyPair.PublicKey, SigCT),
+		"pub: "+base64.StdEncoding.EncodeToString(keyPair.PublicKey), "pub: "+encodedPriv, 1)
+	if _, _, _, _, err := ParseDebugDump(edited); err == nil {
+		t.Fatal("Expected error for a dump containing a private key")
+	}
+	edited = strings.Replace(DebugDump(signature, message, keyPair.PublicKey, SigCT),
+		"msg: "+base64.StdEncoding.EncodeToString(message), "msg: "+encodedPriv, 1)
+	if _, _, _, _, err := ParseDebugDump(edited); err == nil {
+		t.Fatal("Expected error for a dump with a private key as the message")
+	}
+
+	if _, _, _, _, err := ParseDebugDump("not a dump"); err == nil {
+		t.Fatal("Expected error for unrelated text")
+	}
+}
@@ -0,0 +1,80 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// AssertDeterministicAcrossFormats signs msg with priv in every format
+// using randomness derived only from seed, and checks that each format
+// reproduces the same bytes when signed again, that all formats carry
+// the same nonce and decode to the same signature value, and that the
+// padded signature is the compressed one followed by zeros.
+func AssertDeterministicAcrossFormats(seed, msg, priv []byte) error {
+	logN, err := GetLogN(priv)
+	if err != nil {
+		return fmt.Errorf("invalid private key: %w", err)
+	}
+
+	names := map[int]string{SigCompressed: "compressed", SigPadded: "padded", SigCT: "CT"}
+	sigs := map[int][]byte{}
+	for _, sigType := range []int{SigCompressed, SigPadded, SigCT} {
+		first, err := SignDeterministic(msg, priv, sigType, seed)
+		if err != nil {
+			return fmt.Errorf("%s: %w", names[sigType], err)
+		}
+		second, err := SignDeterministic(msg, priv, sigType, seed)
+		if err != nil {
+			return fmt.Errorf("%s: %w", names[sigType], err)
+		}
+		if !bytes.Equal(first, second) {
+			return fmt.Errorf("%s: signing the same input twice gave different signatures", names[sigType])
+		}
+		sigs[sigType] = first
+	}
+
+	comp, padded, ct := sigs[SigCompressed], sigs[SigPadded], sigs[SigCT]
+	if !bytes.Equal(comp[1:1+nonceSize], padded[1:1+nonceSize]) || !bytes.Equal(comp[1:1+nonceSize], ct[1:1+nonceSize]) {
+		return fmt.Errorf("nonces differ across formats")
+	}
+	if !bytes.Equal(padded[:len(comp)], comp) || len(bytes.Trim(padded[len(comp):], "\x00")) != 0 {
+		return fmt.Errorf("padded signature is not the compressed signature plus zero padding")
+	}
+
+	compValue, n := decodeSigValue(comp[1+nonceSize:], uint(logN), false)
+	if n == 0 {
+		return fmt.Errorf("compressed signature does not decode")
+	}
+	ctValue, n := decodeSigValue(ct[1+nonceSize:], uint(logN), true)
+	if n == 0 {
+		return fmt.Errorf("CT signature does not decode")
+	}
+	for i := range compValue {
+		if compValue[i] != ctValue[i] {
+			return fmt.Errorf("compressed and CT signatures differ at coefficient %d: %d vs %d", i, compValue[i], ctValue[i])
+		}
+	}
+	return nil
+}
+
+func TestDeterministicAcrossFormats(t *testing.T) {
+	keys, err := TestKeys(bytes.Repeat([]byte{0x24}, 32))
+	if err != nil {
+		t.Fatalf("Failed to generate test keys: %v", err)
+	}
+
+	seed := bytes.Repeat([]byte{0x99}, 32)
+	for _, logN := range []uint{2, 9, 10} {
+		if err := AssertDeterministicAcrossFormats(seed, []byte("audit"), keys[logN].PrivateKey); err != nil {
+			t.Fatalf("logN %d: %v", logN, err)
+		}
+	}
+
+	// The checks must actually be able to fail
+	if err := AssertDeterministicAcrossFormats(seed[:4], []byte("audit"), keys[9].PrivateKey); err == nil {
+		t.Fatal("Expected error for short seed")
+	}
+}
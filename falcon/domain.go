@@ -0,0 +1,26 @@
+package falcon
+
+// domainHashSize is the length of the domain digest used as the context
+// string by SignWithDomain and VerifyWithDomain
+const domainHashSize = 32
+
+// domainHash returns SHAKE256(domain) truncated to domainHashSize bytes,
+// so that domains of any length fit in a context string
+func domainHash(domain string) []byte {
+	return shake256(domainHashSize, []byte(domain))
+}
+
+// SignWithDomain signs message for one application protocol, such as
+// "tls", so that the signature fails verification under any other
+// domain. It is SignWithContext with SHAKE256(domain) as the context
+// string, so it shares that construction and its separation from plain
+// signatures, and the wire format is unchanged.
+func SignWithDomain(message []byte, domain string, privateKey []byte, sigType int) ([]byte, error) {
+	return SignWithContext(message, domainHash(domain), privateKey, sigType)
+}
+
+// VerifyWithDomain verifies a signature made by SignWithDomain under the
+// same domain
+func VerifyWithDomain(signature, message []byte, domain string, publicKey []byte, sigType int) error {
+	return VerifyWithContext(signature, message, domainHash(domain), publicKey, sigType)
+}
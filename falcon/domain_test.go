@@ -0,0 +1,49 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSignWithDomain(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	message := []byte("handshake transcript")
+
+	for _, sigType := range []int{SigCompressed, SigPadded, SigCT} {
+		signature, err := SignWithDomain(message, "tls", keyPair.PrivateKey, sigType)
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		if err := ValidateSignatureStructure(signature, sigType); err != nil {
+			t.Fatalf("Domain signature has a non-standard encoding: %v", err)
+		}
+		if err := VerifyWithDomain(signature, message, "tls", keyPair.PublicKey, sigType); err != nil {
+			t.Fatalf("Signature verification failed: %v", err)
+		}
+		if err := VerifyWithDomain(signature, message, "blockchain", keyPair.PublicKey, sigType); !errors.Is(err, ErrBadSignature) {
+			t.Fatalf("Expected ErrBadSignature under another domain, got %v", err)
+		}
+		if err := Verify(signature, message, keyPair.PublicKey, SignatureType(sigType)); !errors.Is(err, ErrBadSignature) {
+			t.Fatalf("Expected ErrBadSignature from plain Verify, got %v", err)
+		}
+
+		// The signature is a context signature with the domain digest
+		// as the context
+		if err := VerifyWithContext(signature, message, domainHash("tls"), keyPair.PublicKey, sigType); err != nil {
+			t.Fatalf("Signature does not verify as a context signature: %v", err)
+		}
+		prefixed := append(domainHash("tls"), message...)
+		if err := Verify(signature, prefixed, keyPair.PublicKey, SignatureType(sigType)); !errors.Is(err, ErrBadSignature) {
+			t.Fatalf("Expected ErrBadSignature over the prefixed message, got %v", err)
+		}
+	}
+
+	if _, err := SignWithDomain(message, "tls", keyPair.PublicKey, SigCompressed); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("Expected ErrBadFormat for a public key, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package falcon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrVersionNotAccepted is returned by VerifyVersioned when the
+// signature carries a version that is not in the accepted list
+var ErrVersionNotAccepted = errors.New("signature version not accepted")
+
+// ErrTimestampOutOfRange is returned by VerifyTimestamped when a validly
+// signed timestamp lies outside the accepted window
+var ErrTimestampOutOfRange = errors.New("signature timestamp outside accepted window")
+
+// timestampSize is the length of the encoded timestamp
+const timestampSize = 8
+
+// versionedContext starts the SignWithContext context string of
+// versioned signatures; the version byte follows it
+var versionedContext = []byte("falcon-go versioned signature")
+
+// timestampedContext starts the SignWithContext context string of
+// timestamped signatures; the encoded timestamp follows it
+var timestampedContext = []byte("falcon-go timestamped signature")
+
+// timestampContext returns the context string for an encoded timestamp
+func timestampContext(ts []byte) []byte {
+	return append(append([]byte{}, timestampedContext...), ts...)
+}
+
+// versionContext returns the context string for version
+func versionContext(version byte) []byte {
+	return append(append([]byte{}, versionedContext...), version)
+}
+
+// SignVersioned signs message under a protocol version byte.
+//
+// The signature is SignWithContext over message with versionedContext
+// followed by the version as the context string, so a signature made for
+// one version never verifies under another, and neither a plain
+// signature nor another context signature passes for a versioned one.
+// The returned envelope is version || signature.
+func SignVersioned(message, privateKey []byte, sigType int, version byte) ([]byte, error) {
+	sig, err := SignWithContext(message, versionContext(version), privateKey, sigType)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{version}, sig...), nil
+}
+
+// VerifyVersioned verifies an envelope produced by SignVersioned. The
+// embedded version must appear in acceptedVersions, otherwise
+// ErrVersionNotAccepted is returned without verifying.
+func VerifyVersioned(envelope, message, publicKey []byte, sigType int, acceptedVersions []byte) error {
+	if len(envelope) == 0 {
+		return fmt.Errorf("%w: empty envelope", ErrBadLength)
+	}
+
+	version := envelope[0]
+	if bytes.IndexByte(acceptedVersions, version) < 0 {
+		return ErrVersionNotAccepted
+	}
+
+	return VerifyWithContext(envelope[1:], message, versionContext(version), publicKey, sigType)
+}
+
+// SignTimestamped signs message together with the time t.
+//
+// ts is t as Unix nanoseconds in a 64-bit big-endian integer. The
+// signature is SignWithContext over message with timestampedContext
+// followed by ts as the context string, which keeps it apart from plain,
+// versioned and other context signatures. The returned envelope is
+// ts || signature.
+func SignTimestamped(message, privateKey []byte, sigType int, t time.Time) ([]byte, error) {
+	ts := binary.BigEndian.AppendUint64(nil, uint64(t.UnixNano()))
+	sig, err := SignWithContext(message, timestampContext(ts), privateKey, sigType)
+	if err != nil {
+		return nil, err
+	}
+	return append(ts, sig...), nil
+}
+
+// VerifyTimestamped verifies an envelope produced by SignTimestamped and
+// returns ErrTimestampOutOfRange if the signed timestamp is not within
+// now ± maxSkew
+func VerifyTimestamped(envelope, message, publicKey []byte, sigType int, now time.Time, maxSkew time.Duration) error {
+	if len(envelope) < timestampSize {
+		return fmt.Errorf("%w: envelope too short for timestamp", ErrBadLength)
+	}
+
+	ts := envelope[:timestampSize]
+	if err := VerifyWithContext(envelope[timestampSize:], message, timestampContext(ts), publicKey, sigType); err != nil {
+		return err
+	}
+
+	signedAt := time.Unix(0, int64(binary.BigEndian.Uint64(ts)))
+	if signedAt.Before(now.Add(-maxSkew)) || signedAt.After(now.Add(maxSkew)) {
+		return ErrTimestampOutOfRange
+	}
+	return nil
+}
+
+// maxKeyIDSize is the largest key identifier SignWithKeyID accepts
+const maxKeyIDSize = 255
+
+// SignWithKeyID signs message and tags the result with keyID.
+//
+// The returned envelope is len(keyID) || keyID || signature with a
+// single length byte. The key ID is only a routing hint for picking the
+// public key: it is not covered by the signature, which is a regular
+// Falcon signature over message.
+func SignWithKeyID(message, privateKey []byte, sigType int, keyID []byte) ([]byte, error) {
+	if len(keyID) > maxKeyIDSize {
+		return nil, fmt.Errorf("key ID longer than %d bytes", maxKeyIDSize)
+	}
+	sig, err := Sign(message, privateKey, SignatureType(sigType))
+	if err != nil {
+		return nil, err
+	}
+	envelope := make([]byte, 0, 1+len(keyID)+len(sig))
+	envelope = append(envelope, byte(len(keyID)))
+	envelope = append(envelope, keyID...)
+	return append(envelope, sig...), nil
+}
+
+// splitKeyID splits an envelope produced by SignWithKeyID into the key
+// ID and the signature
+func splitKeyID(envelope []byte) ([]byte, []byte, error) {
+	if len(envelope) == 0 {
+		return nil, nil, fmt.Errorf("%w: empty envelope", ErrBadLength)
+	}
+	n := int(envelope[0])
+	if len(envelope) < 1+n {
+		return nil, nil, fmt.Errorf("%w: envelope too short for key ID", ErrBadLength)
+	}
+	return envelope[1 : 1+n], envelope[1+n:], nil
+}
+
+// KeyIDFromSignature returns the key ID embedded by SignWithKeyID. The
+// ID is not authenticated until the signature has been verified.
+func KeyIDFromSignature(envelope []byte) ([]byte, error) {
+	keyID, _, err := splitKeyID(envelope)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{}, keyID...), nil
+}
+
+// VerifyWithKeyID verifies an envelope produced by SignWithKeyID, using
+// resolve to look up the public key for the embedded key ID
+func VerifyWithKeyID(envelope, message []byte, sigType int, resolve func(keyID []byte) ([]byte, error)) error {
+	keyID, sig, err := splitKeyID(envelope)
+	if err != nil {
+		return err
+	}
+	publicKey, err := resolve(keyID)
+	if err != nil {
+		return fmt.Errorf("resolving key ID %x: %w", keyID, err)
+	}
+	return Verify(sig, message, publicKey, SignatureType(sigType))
+}
@@ -0,0 +1,171 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSignVersioned(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	message := []byte("versioned payload")
+	envelope, err := SignVersioned(message, keyPair.PrivateKey, SigCompressed, 2)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	if err := VerifyVersioned(envelope, message, keyPair.PublicKey, SigCompressed, []byte{1, 2}); err != nil {
+		t.Fatalf("Failed to verify accepted version: %v", err)
+	}
+
+	err = VerifyVersioned(envelope, message, keyPair.PublicKey, SigCompressed, []byte{1})
+	if !errors.Is(err, ErrVersionNotAccepted) {
+		t.Fatalf("Expected ErrVersionNotAccepted, got %v", err)
+	}
+
+	// Relabeling the envelope with another accepted version must fail,
+	// since the version is covered by the signature
+	relabeled := append([]byte{1}, envelope[1:]...)
+	if err := VerifyVersioned(relabeled, message, keyPair.PublicKey, SigCompressed, []byte{1, 2}); err == nil {
+		t.Fatal("Verification succeeded for relabeled version")
+	}
+
+	// The inner signature is not valid over the bare message, nor over
+	// the version byte and message together
+	if err := Verify(envelope[1:], message, keyPair.PublicKey, SigCompressed); err == nil {
+		t.Fatal("Versioned signature verified over the bare message")
+	}
+	prefixed := append([]byte{2}, message...)
+	if err := Verify(envelope[1:], prefixed, keyPair.PublicKey, SigCompressed); err == nil {
+		t.Fatal("Versioned signature verified as a plain signature")
+	}
+
+	// Nor does a plain signature over version || message pass as an
+	// envelope
+	plain, err := Sign(prefixed, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	if err := VerifyVersioned(append([]byte{2}, plain...), message, keyPair.PublicKey, SigCompressed, []byte{2}); err == nil {
+		t.Fatal("Plain signature verified as a versioned envelope")
+	}
+
+	if err := VerifyVersioned(nil, message, keyPair.PublicKey, SigCompressed, []byte{2}); err == nil {
+		t.Fatal("Expected error for empty envelope")
+	}
+}
+
+func TestSignTimestamped(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	message := []byte("session token")
+	issued := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	token, err := SignTimestamped(message, keyPair.PrivateKey, SigCompressed, issued)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	// Fresh: within the window on either side
+	for _, now := range []time.Time{issued, issued.Add(30 * time.Second), issued.Add(-30 * time.Second)} {
+		if err := VerifyTimestamped(token, message, keyPair.PublicKey, SigCompressed, now, time.Minute); err != nil {
+			t.Fatalf("Failed to verify fresh token at %v: %v", now, err)
+		}
+	}
+
+	// Expired, or issued too far in the future
+	for _, now := range []time.Time{issued.Add(2 * time.Minute), issued.Add(-2 * time.Minute)} {
+		err := VerifyTimestamped(token, message, keyPair.PublicKey, SigCompressed, now, time.Minute)
+		if !errors.Is(err, ErrTimestampOutOfRange) {
+			t.Fatalf("Expected ErrTimestampOutOfRange at %v, got %v", now, err)
+		}
+	}
+
+	// The timestamp is covered by the signature
+	forged := append([]byte{}, token...)
+	forged[7] ^= 0x01
+	err = VerifyTimestamped(forged, message, keyPair.PublicKey, SigCompressed, issued, time.Minute)
+	if err == nil || errors.Is(err, ErrTimestampOutOfRange) {
+		t.Fatalf("Expected signature failure for altered timestamp, got %v", err)
+	}
+
+	// Neither a plain signature over ts || message nor a versioned
+	// envelope passes as a timestamped one
+	plain, err := Sign(append(append([]byte{}, token[:timestampSize]...), message...), keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	if err := VerifyTimestamped(append(token[:timestampSize:timestampSize], plain...), message, keyPair.PublicKey, SigCompressed, issued, time.Minute); err == nil {
+		t.Fatal("Plain signature verified as a timestamped envelope")
+	}
+	if err := Verify(token[timestampSize:], append(append([]byte{}, token[:timestampSize]...), message...), keyPair.PublicKey, SigCompressed); err == nil {
+		t.Fatal("Timestamped signature verified as a plain signature")
+	}
+}
+
+func TestSignWithKeyID(t *testing.T) {
+	keys := map[string]*KeyPair{}
+	for _, id := range []string{"alpha", "beta"} {
+		keyPair, err := GenerateKeyPair(9)
+		if err != nil {
+			t.Fatalf("Failed to generate key pair: %v", err)
+		}
+		keys[id] = keyPair
+	}
+	resolve := func(keyID []byte) ([]byte, error) {
+		keyPair, ok := keys[string(keyID)]
+		if !ok {
+			return nil, errors.New("unknown key")
+		}
+		return keyPair.PublicKey, nil
+	}
+
+	message := []byte("routed payload")
+	envelope, err := SignWithKeyID(message, keys["beta"].PrivateKey, SigCompressed, []byte("beta"))
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	keyID, err := KeyIDFromSignature(envelope)
+	if err != nil {
+		t.Fatalf("Failed to extract key ID: %v", err)
+	}
+	if string(keyID) != "beta" {
+		t.Fatalf("Expected key ID %q, got %q", "beta", keyID)
+	}
+
+	if err := VerifyWithKeyID(envelope, message, SigCompressed, resolve); err != nil {
+		t.Fatalf("Failed to verify message: %v", err)
+	}
+
+	// The key ID is not part of the signature itself
+	if err := Verify(envelope[1+len(keyID):], message, keys["beta"].PublicKey, SigCompressed); err != nil {
+		t.Fatalf("Inner signature failed plain verification: %v", err)
+	}
+
+	// Pointing the hint at another key makes verification fail
+	retagged := append([]byte{5}, append([]byte("alpha"), envelope[1+len(keyID):]...)...)
+	if err := VerifyWithKeyID(retagged, message, SigCompressed, resolve); err == nil {
+		t.Fatal("Verification succeeded with the wrong key")
+	}
+
+	unknown := append([]byte{3}, append([]byte("xyz"), envelope[1+len(keyID):]...)...)
+	if err := VerifyWithKeyID(unknown, message, SigCompressed, resolve); err == nil {
+		t.Fatal("Verification succeeded for an unknown key ID")
+	}
+
+	if _, err := KeyIDFromSignature([]byte{10, 1, 2}); err == nil {
+		t.Fatal("Expected error for truncated envelope")
+	}
+	if _, err := SignWithKeyID(message, keys["beta"].PrivateKey, SigCompressed, make([]byte, 256)); err == nil {
+		t.Fatal("Expected error for oversized key ID")
+	}
+}
@@ -0,0 +1,71 @@
+package falcon
+
+import "errors"
+
+// falconErr is an error carrying one of the C library's FALCON_ERR_*
+// codes
+type falconErr struct {
+	code     int
+	msg      string
+	sentinel bool
+}
+
+func (e *falconErr) Error() string {
+	return e.msg
+}
+
+// Is makes every coded error match the sentinel for its code, so that a
+// more specific error such as an invalid signature type still satisfies
+// errors.Is(err, ErrBadArgument)
+func (e *falconErr) Is(target error) bool {
+	t, ok := target.(*falconErr)
+	return ok && t.sentinel && t.code == e.code
+}
+
+// newError returns an error with the given code and message
+func newError(code int, msg string) error {
+	return &falconErr{code: code, msg: msg}
+}
+
+// newSentinel returns the error that all errors with code match
+func newSentinel(code int, msg string) error {
+	return &falconErr{code: code, msg: msg, sentinel: true}
+}
+
+// Sentinel errors, one per C library error code. Every error this
+// package reports for a FALCON_ERR_* condition, whether returned by C or
+// detected on the Go side, matches the corresponding sentinel with
+// errors.Is.
+var (
+	ErrRandomFailed    = newSentinel(ErrRandom, "random number generation failed")
+	ErrBufferTooSmall  = newSentinel(ErrSize, "buffer too small")
+	ErrInvalidFormat   = newSentinel(ErrFormat, "invalid format")
+	ErrBadSignature    = newSentinel(ErrBadSig, "invalid signature")
+	ErrBadArgument     = newSentinel(ErrBadArg, "invalid argument")
+	ErrInternalFailure = newSentinel(ErrInternal, "internal error")
+)
+
+// ErrUnsupportedPlatform is returned by every operation that needs the C
+// library when the package is built without cgo, e.g. for GOOS=js or
+// with CGO_ENABLED=0
+var ErrUnsupportedPlatform = errors.New("falcon: built without cgo, the C implementation is unavailable")
+
+// Argument errors detected before calling into C
+var (
+	errInvalidLogN    = newError(ErrBadArg, "logN must be between 1 and 10")
+	errInvalidSigType = newError(ErrBadArg, "invalid signature type")
+)
+
+// ErrorCode returns the numeric C library error code (ErrRandom, ErrSize,
+// ErrFormat, ErrBadSig, ErrBadArg or ErrInternal) carried by err or any
+// error it wraps. Failures reported by C keep their original code;
+// invalid arguments and malformed inputs caught on the Go side carry the
+// code C would have returned for them. Errors with no C counterpart,
+// such as policy rejections, report false.
+func ErrorCode(err error) (int, bool) {
+	var fe *falconErr
+	if errors.As(err, &fe) {
+		return fe.code, true
+	}
+	return 0, false
+}
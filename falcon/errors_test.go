@@ -0,0 +1,124 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorCode(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	message := []byte("coded")
+	signature, err := Sign(message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	// Keygen: a system RNG failure surfaces FALCON_ERR_RANDOM
+	seedFromSystem = func(*PRNGContext) error { return falconError(ErrRandom) }
+	_, keygenErr := GenerateKeyPair(9)
+	seedFromSystem = (*PRNGContext).InitFromSystem
+
+	// Sign: a truncated private key is rejected by the C decoder
+	_, signErr := Sign(message, keyPair.PrivateKey[:len(keyPair.PrivateKey)-1], SigCompressed)
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"keygen rng", keygenErr, ErrRandom},
+		{"keygen logN", func() error { _, err := GenerateKeyPair(0); return err }(), ErrBadArg},
+		{"sign key", signErr, ErrFormat},
+		{"sign type", func() error { _, err := Sign(message, keyPair.PrivateKey, 9); return err }(), ErrBadArg},
+		{"verify bad signature", Verify(signature, []byte("other"), keyPair.PublicKey, SigCompressed), ErrBadSig},
+		{"verify malformed", Verify(signature[:3], message, keyPair.PublicKey, SigCompressed), ErrFormat},
+	}
+	for _, tt := range tests {
+		if tt.err == nil {
+			t.Fatalf("%s: expected an error", tt.name)
+		}
+		code, ok := ErrorCode(tt.err)
+		if !ok || code != tt.want {
+			t.Errorf("%s: ErrorCode(%v) = %d, %v; want %d", tt.name, tt.err, code, ok, tt.want)
+		}
+	}
+
+	if _, ok := ErrorCode(errors.New("unrelated")); ok {
+		t.Fatal("Unrelated error reported a code")
+	}
+	if _, ok := ErrorCode(nil); ok {
+		t.Fatal("Nil error reported a code")
+	}
+}
+
+func TestSentinelErrors(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	message := []byte("sentinel")
+	signature, err := Sign(message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"bad signature", Verify(signature, []byte("other"), keyPair.PublicKey, SigCompressed), ErrBadSignature},
+		{"malformed", Verify(signature[:3], message, keyPair.PublicKey, SigCompressed), ErrInvalidFormat},
+		{"bad argument", func() error { _, err := Sign(message, keyPair.PrivateKey, 9); return err }(), ErrBadArgument},
+		{"C bad argument", falconError(ErrBadArg), ErrBadArgument},
+		{"C random", falconError(ErrRandom), ErrRandomFailed},
+		{"C size", falconError(ErrSize), ErrBufferTooSmall},
+		{"C internal", falconError(ErrInternal), ErrInternalFailure},
+	}
+	sentinels := []error{ErrRandomFailed, ErrBufferTooSmall, ErrInvalidFormat, ErrBadSignature, ErrBadArgument, ErrInternalFailure}
+	for _, tt := range tests {
+		for _, s := range sentinels {
+			if got := errors.Is(tt.err, s); got != (s == tt.want) {
+				t.Errorf("%s: errors.Is(%v, %v) = %v", tt.name, tt.err, s, got)
+			}
+		}
+	}
+
+	if !errors.Is(ErrBadLength, ErrBadFormat) || ErrBadFormat != ErrInvalidFormat {
+		t.Fatal("ErrBadFormat is not ErrInvalidFormat")
+	}
+	if errors.Is(errInvalidLogN, errInvalidSigType) {
+		t.Fatal("Distinct non-sentinel errors matched each other")
+	}
+}
+
+func TestTamperedSignatureIsBadSignature(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	message := []byte("tamper")
+	signature, err := Sign(message, keyPair.PrivateKey, SigPadded)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	// Flipping a nonce bit keeps the encoding valid but breaks the signature
+	signature[1] ^= 1
+	err = Verify(signature, message, keyPair.PublicKey, SigPadded)
+	if !errors.Is(err, ErrBadSignature) {
+		t.Fatalf("Expected ErrBadSignature, got %v", err)
+	}
+	if wrapped := fmt.Errorf("checking release: %w", err); !errors.Is(wrapped, ErrBadSignature) {
+		t.Fatal("Wrapped error no longer matches ErrBadSignature")
+	}
+	if errors.Is(err, ErrBufferTooSmall) || errors.Is(err, ErrInvalidFormat) {
+		t.Fatalf("Bad signature matched an unrelated sentinel: %v", err)
+	}
+}
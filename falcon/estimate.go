@@ -0,0 +1,59 @@
+package falcon
+
+import (
+	"sync"
+	"time"
+)
+
+// keygenSamples is the number of key generations timed to calibrate an
+// estimate; the fastest is kept, since key generation occasionally
+// restarts and one slow run says little about the typical cost
+const keygenSamples = 5
+
+var keygenEstimates struct {
+	sync.Mutex
+	byLogN map[uint]time.Duration
+}
+
+// EstimateKeygenDuration returns a rough estimate of how long
+// GenerateKeyPair takes for the given degree on this machine. The first
+// call for a degree calibrates by timing a few key generations and
+// keeping the fastest; later calls return the cached value. Invalid
+// degrees return zero.
+func EstimateKeygenDuration(logN uint) time.Duration {
+	if logN < 1 || logN > 10 {
+		return 0
+	}
+
+	keygenEstimates.Lock()
+	d, ok := keygenEstimates.byLogN[logN]
+	keygenEstimates.Unlock()
+	if ok {
+		return d
+	}
+
+	// Calibrate without holding the lock, so that callers for other
+	// degrees, or ones already cached, are not held up
+	for i := 0; i < keygenSamples; i++ {
+		start := time.Now()
+		if _, err := GenerateKeyPair(logN); err != nil {
+			return 0
+		}
+		if elapsed := time.Since(start); i == 0 || elapsed < d {
+			d = elapsed
+		}
+	}
+
+	keygenEstimates.Lock()
+	defer keygenEstimates.Unlock()
+	if cached, ok := keygenEstimates.byLogN[logN]; ok {
+		// A concurrent caller calibrated first; keep its value so every
+		// caller sees the same estimate
+		return cached
+	}
+	if keygenEstimates.byLogN == nil {
+		keygenEstimates.byLogN = make(map[uint]time.Duration)
+	}
+	keygenEstimates.byLogN[logN] = d
+	return d
+}
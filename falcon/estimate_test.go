@@ -0,0 +1,33 @@
+//go:build cgo
+
+package falcon
+
+import "testing"
+
+func TestEstimateKeygenDuration(t *testing.T) {
+	est9 := EstimateKeygenDuration(9)
+	est10 := EstimateKeygenDuration(10)
+	t.Logf("Estimated keygen: logN=9 %v, logN=10 %v", est9, est10)
+
+	if est9 <= 0 || est10 <= 0 {
+		t.Fatal("Expected positive estimates")
+	}
+
+	// Each estimate is the fastest of several runs, which keeps a single
+	// slow run from inverting the order
+	if est9 >= est10 {
+		t.Errorf("Expected logN=9 estimate (%v) to be smaller than logN=10 (%v)", est9, est10)
+	}
+
+	// Cached values must be returned on subsequent calls
+	if again := EstimateKeygenDuration(9); again != est9 {
+		t.Errorf("Estimate not cached: got %v, want %v", again, est9)
+	}
+	if again := EstimateKeygenDuration(10); again != est10 {
+		t.Errorf("Estimate not cached: got %v, want %v", again, est10)
+	}
+
+	if d := EstimateKeygenDuration(11); d != 0 {
+		t.Errorf("Expected zero estimate for invalid logN, got %v", d)
+	}
+}
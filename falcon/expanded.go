@@ -0,0 +1,106 @@
+package falcon
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// ErrKeyDestroyed is returned when signing with an ExpandedKey after
+// Destroy has been called on it
+var ErrKeyDestroyed = newError(ErrBadArg, "expanded key destroyed")
+
+// ExpandedKey is a private key expanded once into the FFT tree the signer
+// works on, so that signing many messages with the same key skips the
+// per-call expansion done by Sign. The tree is much larger than the
+// encoded key and lives on the C heap, outside the reach of the Go
+// garbage collector; it is wiped and freed by Destroy, or by a finalizer
+// if Destroy is never called. An ExpandedKey is safe for concurrent use.
+type ExpandedKey struct {
+	mu   sync.RWMutex
+	logN uint
+	key  []byte // C heap, nil once destroyed
+}
+
+// SigningTree is the name the C library uses for an expanded key
+type SigningTree = ExpandedKey
+
+// ExpandPrivateKey expands an encoded private key for repeated signing
+func ExpandPrivateKey(privateKey []byte) (*ExpandedKey, error) {
+	logN, err := checkPrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	key := cAlloc(expandedKeySize(logN))
+	if err := expandPrivateKeyInto(key, privateKey, logN); err != nil {
+		cFree(key)
+		return nil, err
+	}
+
+	k := &ExpandedKey{logN: logN, key: key}
+	runtime.SetFinalizer(k, (*ExpandedKey).Destroy)
+	return k, nil
+}
+
+// LogN returns the degree (log2) of the key
+func (k *ExpandedKey) LogN() int {
+	return int(k.logN)
+}
+
+// MemoryBytes returns the size of the expanded key held outside the Go
+// heap, or 0 once it has been destroyed
+func (k *ExpandedKey) MemoryBytes() int {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return len(k.key)
+}
+
+// Destroy wipes and frees the expanded key. It is safe to call more than
+// once; signing afterwards returns ErrKeyDestroyed.
+func (k *ExpandedKey) Destroy() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.key == nil {
+		return
+	}
+	SecureZero(k.key)
+	cFree(k.key)
+	k.key = nil
+	runtime.SetFinalizer(k, nil)
+}
+
+// SignWith signs message with the expanded key. The signature is the
+// same kind Sign produces and verifies with Verify.
+func (k *ExpandedKey) SignWith(message []byte, sigType int) ([]byte, error) {
+	return SignWithTree(message, k, sigType, nil)
+}
+
+// SignWithTree signs message with an expanded key, drawing randomness
+// from rng. A nil rng uses a fresh system-seeded one; a caller-supplied
+// rng must be in output mode, as for SignWithRNG, and is advanced, so
+// reusing it yields a different signature each call.
+func SignWithTree(message []byte, tree *SigningTree, sigType int, rng *PRNGContext) ([]byte, error) {
+	if tree == nil {
+		return nil, newError(ErrBadArg, "nil signing tree")
+	}
+	if rng != nil && !rng.flipped {
+		return nil, ErrContextNotFlipped
+	}
+	if rng == nil {
+		var err error
+		if rng, err = newSystemPRNG(); err != nil {
+			return nil, fmt.Errorf("failed to initialize RNG: %w", err)
+		}
+	}
+
+	tree.mu.RLock()
+	defer tree.mu.RUnlock()
+	if tree.key == nil {
+		return nil, ErrKeyDestroyed
+	}
+	sig, err := signTree(rng, message, tree.key, tree.logN, sigType)
+	// Keep the finalizer from freeing the tree while C is using it
+	runtime.KeepAlive(tree)
+	return sig, err
+}
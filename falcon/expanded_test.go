@@ -0,0 +1,85 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExpandedKey(t *testing.T) {
+	for _, logN := range []uint{2, 9, 10} {
+		keyPair, err := GenerateKeyPair(logN)
+		if err != nil {
+			t.Fatalf("Failed to generate key pair: %v", err)
+		}
+		key, err := ExpandPrivateKey(keyPair.PrivateKey)
+		if err != nil {
+			t.Fatalf("Failed to expand private key: %v", err)
+		}
+
+		message := []byte("bulk message")
+		for _, sigType := range []int{SigCompressed, SigPadded, SigCT} {
+			signature, err := key.SignWith(message, sigType)
+			if err != nil {
+				t.Fatalf("Failed to sign with expanded key: %v", err)
+			}
+			if err := Verify(signature, message, keyPair.PublicKey, SignatureType(sigType)); err != nil {
+				t.Fatalf("logN %d type %d: signature failed verification: %v", logN, sigType, err)
+			}
+		}
+
+		if _, err := key.SignWith(message, 9); err == nil {
+			t.Fatal("Expected error for invalid signature type")
+		}
+	}
+
+	if _, err := ExpandPrivateKey([]byte{0x59, 0x00}); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("Expected ErrBadFormat for truncated key, got %v", err)
+	}
+}
+
+func TestSigningTreeLifecycle(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	tree, err := ExpandPrivateKey(keyPair.PrivateKey)
+	if err != nil {
+		t.Fatalf("Failed to expand private key: %v", err)
+	}
+	if tree.LogN() != 9 {
+		t.Fatalf("LogN() = %d, want 9", tree.LogN())
+	}
+	if tree.MemoryBytes() != expandedKeySize(9) {
+		t.Fatalf("MemoryBytes() = %d, want %d", tree.MemoryBytes(), expandedKeySize(9))
+	}
+
+	var rng PRNGContext
+	rng.InitFromSeed([]byte("signing tree test"))
+	message := []byte("tree message")
+	signature, err := SignWithTree(message, tree, SigPadded, &rng)
+	if err != nil {
+		t.Fatalf("Failed to sign with tree: %v", err)
+	}
+	if err := Verify(signature, message, keyPair.PublicKey, SigPadded); err != nil {
+		t.Fatalf("Tree signature failed verification: %v", err)
+	}
+	var unflipped PRNGContext
+	unflipped.Init()
+	if _, err := SignWithTree(message, tree, SigPadded, &unflipped); !errors.Is(err, ErrContextNotFlipped) {
+		t.Fatalf("Expected ErrContextNotFlipped, got %v", err)
+	}
+
+	tree.Destroy()
+	tree.Destroy()
+	if tree.MemoryBytes() != 0 {
+		t.Fatalf("MemoryBytes() = %d after Destroy", tree.MemoryBytes())
+	}
+	if _, err := tree.SignWith(message, SigPadded); !errors.Is(err, ErrKeyDestroyed) {
+		t.Fatalf("Expected ErrKeyDestroyed, got %v", err)
+	}
+	if _, err := SignWithTree(message, nil, SigPadded, nil); err == nil {
+		t.Fatal("Expected error for nil tree")
+	}
+}
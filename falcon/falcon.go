@@ -1,3 +1,5 @@
+//go:build cgo
+
 // internal/falcon/falcon.go
 
 package falcon
@@ -10,42 +12,38 @@ package falcon
 #include <stdlib.h>
 
 // Wrapper functions for macros
-size_t falcon_privkey_size(unsigned logn) {
-    return FALCON_PRIVKEY_SIZE(logn);
+size_t falcon_tmpsize_keygen(unsigned logn) {
+    return FALCON_TMPSIZE_KEYGEN(logn);
 }
 
-size_t falcon_pubkey_size(unsigned logn) {
-    return FALCON_PUBKEY_SIZE(logn);
+size_t falcon_tmpsize_makepub(unsigned logn) {
+    return FALCON_TMPSIZE_MAKEPUB(logn);
 }
 
-size_t falcon_sig_compressed_maxsize(unsigned logn) {
-    return FALCON_SIG_COMPRESSED_MAXSIZE(logn);
+size_t falcon_tmpsize_signdyn(unsigned logn) {
+    return FALCON_TMPSIZE_SIGNDYN(logn);
 }
 
-size_t falcon_sig_padded_size(unsigned logn) {
-    return FALCON_SIG_PADDED_SIZE(logn);
+size_t falcon_tmpsize_verify(unsigned logn) {
+    return FALCON_TMPSIZE_VERIFY(logn);
 }
 
-size_t falcon_sig_ct_size(unsigned logn) {
-    return FALCON_SIG_CT_SIZE(logn);
+size_t falcon_tmpsize_signtree(unsigned logn) {
+    return FALCON_TMPSIZE_SIGNTREE(logn);
 }
 
-size_t falcon_tmpsize_keygen(unsigned logn) {
-    return FALCON_TMPSIZE_KEYGEN(logn);
+size_t falcon_tmpsize_expandpriv(unsigned logn) {
+    return FALCON_TMPSIZE_EXPANDPRIV(logn);
 }
 
-size_t falcon_tmpsize_signdyn(unsigned logn) {
-    return FALCON_TMPSIZE_SIGNDYN(logn);
-}
-
-size_t falcon_tmpsize_verify(unsigned logn) {
-    return FALCON_TMPSIZE_VERIFY(logn);
+size_t falcon_expandedkey_size(unsigned logn) {
+    return FALCON_EXPANDEDKEY_SIZE(logn);
 }
 */
 import "C"
 import (
-	"errors"
 	"fmt"
+	"runtime"
 	"unsafe"
 )
 
@@ -66,31 +64,31 @@ const (
 	SigCT         = C.FALCON_SIG_CT
 )
 
-// Wrapper functions for size calculations
-func privateKeySize(logN uint) int {
-	return int(C.falcon_privkey_size(C.uint(logN)))
-}
-
-func publicKeySize(logN uint) int {
-	return int(C.falcon_pubkey_size(C.uint(logN)))
-}
-
-func sigCompressedMaxSize(logN uint) int {
-	return int(C.falcon_sig_compressed_maxsize(C.uint(logN)))
-}
-
-func sigPaddedSize(logN uint) int {
-	return int(C.falcon_sig_padded_size(C.uint(logN)))
-}
-
-func sigCTSize(logN uint) int {
-	return int(C.falcon_sig_ct_size(C.uint(logN)))
+// ptr returns a pointer to the first element of b suitable for passing
+// to C together with len(b). For an empty slice it returns nil rather
+// than indexing b[0], which would panic; every C function called by this
+// package accepts a NULL pointer when the accompanying length is zero.
+//
+// Because the slice is reduced to a bare pointer, every C call taking
+// ptr(b) is followed by runtime.KeepAlive(b), so the backing array is
+// guaranteed to stay reachable until C has returned.
+func ptr(b []byte) unsafe.Pointer {
+	if len(b) == 0 {
+		return nil
+	}
+	return unsafe.Pointer(&b[0])
 }
 
+// Wrapper functions for scratch size calculations; the encoded object
+// sizes are computed in Go (see sizes.go)
 func tmpSizeKeygen(logN uint) int {
 	return int(C.falcon_tmpsize_keygen(C.uint(logN)))
 }
 
+func tmpSizeMakePub(logN uint) int {
+	return int(C.falcon_tmpsize_makepub(C.uint(logN)))
+}
+
 func tmpSizeSignDyn(logN uint) int {
 	return int(C.falcon_tmpsize_signdyn(C.uint(logN)))
 }
@@ -99,19 +97,26 @@ func tmpSizeVerify(logN uint) int {
 	return int(C.falcon_tmpsize_verify(C.uint(logN)))
 }
 
-// KeyPair represents a Falcon key pair
-type KeyPair struct {
-	PublicKey  []byte
-	PrivateKey []byte
+func tmpSizeSignTree(logN uint) int {
+	return int(C.falcon_tmpsize_signtree(C.uint(logN)))
+}
+
+func tmpSizeExpandPriv(logN uint) int {
+	return int(C.falcon_tmpsize_expandpriv(C.uint(logN)))
+}
+
+func expandedKeySize(logN uint) int {
+	return int(C.falcon_expandedkey_size(C.uint(logN)))
 }
 
 // GetLogN returns the Falcon degree from an encoded object (private key, public key, or signature)
 func GetLogN(data []byte) (int, error) {
 	if len(data) == 0 {
-		return 0, errors.New("empty input data")
+		return 0, fmt.Errorf("%w: empty input data", ErrBadLength)
 	}
 
-	result := C.falcon_get_logn(unsafe.Pointer(&data[0]), C.size_t(len(data)))
+	result := C.falcon_get_logn(ptr(data), C.size_t(len(data)))
+	runtime.KeepAlive(data)
 	if result < 0 {
 		return 0, falconError(result)
 	}
@@ -121,32 +126,44 @@ func GetLogN(data []byte) (int, error) {
 // GenerateKeyPair generates a new Falcon key pair for the given degree (logN)
 func GenerateKeyPair(logN uint) (*KeyPair, error) {
 	if logN < 1 || logN > 10 {
-		return nil, errors.New("logN must be between 1 and 10")
+		return nil, errInvalidLogN
+	}
+
+	// Initialize PRNG
+	rng, err := newSystemPRNG()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize RNG: %w", err)
 	}
 
+	return keygen(rng, logN)
+}
+
+// keygen generates a key pair drawing randomness from rng. logN must
+// already have been validated.
+func keygen(rng *PRNGContext, logN uint) (*KeyPair, error) {
+	defer acquire()()
+
 	privKeySize := privateKeySize(logN)
 	pubKeySize := publicKeySize(logN)
-	tmpSize := tmpSizeKeygen(logN)
 
 	privKey := make([]byte, privKeySize)
 	pubKey := make([]byte, pubKeySize)
-	tmp := make([]byte, tmpSize)
-
-	// Initialize PRNG
-	rng := &PRNGContext{}
-	if err := rng.InitFromSystem(); err != nil {
-		return nil, fmt.Errorf("failed to initialize RNG: %w", err)
-	}
+	tmp, release := getScratch(scratchKeygen, logN)
+	defer release()
 
 	result := C.falcon_keygen_make(
 		&rng.ctx,
 		C.uint(logN),
-		unsafe.Pointer(&privKey[0]), C.size_t(len(privKey)),
-		unsafe.Pointer(&pubKey[0]), C.size_t(len(pubKey)),
-		unsafe.Pointer(&tmp[0]), C.size_t(len(tmp)),
+		ptr(privKey), C.size_t(len(privKey)),
+		ptr(pubKey), C.size_t(len(pubKey)),
+		ptr(tmp), C.size_t(len(tmp)),
 	)
+	runtime.KeepAlive(privKey)
+	runtime.KeepAlive(pubKey)
+	runtime.KeepAlive(tmp)
 
 	if result != 0 {
+		SecureZero(privKey)
 		return nil, falconError(result)
 	}
 
@@ -156,47 +173,193 @@ func GenerateKeyPair(logN uint) (*KeyPair, error) {
 	}, nil
 }
 
-// Sign generates a signature for the given message using the private key
-func Sign(message, privateKey []byte, sigType int) ([]byte, error) {
+// derivePublicKey recomputes the public key matching an encoded private key
+func derivePublicKey(privateKey []byte) ([]byte, error) {
 	logN, err := GetLogN(privateKey)
 	if err != nil {
 		return nil, fmt.Errorf("invalid private key: %w", err)
 	}
+	defer acquire()()
 
-	var sigLen C.size_t
-	var sigSize int
+	pubKey := make([]byte, publicKeySize(uint(logN)))
+	tmp := make([]byte, tmpSizeMakePub(uint(logN)))
+	defer SecureZero(tmp)
 
-	// Calculate maximum buffer size based on signature type
-	switch sigType {
-	case SigCompressed:
-		sigSize = sigCompressedMaxSize(uint(logN))
-	case SigPadded:
-		sigSize = sigPaddedSize(uint(logN))
-	case SigCT:
-		sigSize = sigCTSize(uint(logN))
-	default:
-		return nil, errors.New("invalid signature type")
+	result := C.falcon_make_public(
+		ptr(pubKey), C.size_t(len(pubKey)),
+		ptr(privateKey), C.size_t(len(privateKey)),
+		ptr(tmp), C.size_t(len(tmp)),
+	)
+	runtime.KeepAlive(pubKey)
+	runtime.KeepAlive(privateKey)
+	runtime.KeepAlive(tmp)
+
+	if result != 0 {
+		return nil, falconError(result)
 	}
 
-	// Create buffers
-	signature := make([]byte, sigSize)
-	sigLen = C.size_t(sigSize)
-	tmpSize := tmpSizeSignDyn(uint(logN))
-	tmp := make([]byte, tmpSize)
+	return pubKey, nil
+}
+
+// expandPrivateKey computes the expanded form of an encoded private key,
+// which speeds up signing at the cost of memory. The layout is specific
+// to the C implementation and must not be moved to a different 8-byte
+// alignment; Go heap allocations of this size always satisfy that.
+func expandPrivateKey(privateKey []byte) ([]byte, error) {
+	logN, err := GetLogN(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	expanded := make([]byte, expandedKeySize(uint(logN)))
+	if err := expandPrivateKeyInto(expanded, privateKey, uint(logN)); err != nil {
+		return nil, err
+	}
+	return expanded, nil
+}
+
+// expandPrivateKeyInto writes the expanded form of privateKey, of degree
+// logN, into expanded, which must be expandedKeySize(logN) bytes
+func expandPrivateKeyInto(expanded, privateKey []byte, logN uint) error {
+	defer acquire()()
+
+	tmp := make([]byte, tmpSizeExpandPriv(logN))
+	defer SecureZero(tmp)
+
+	result := C.falcon_expand_privkey(
+		ptr(expanded), C.size_t(len(expanded)),
+		ptr(privateKey), C.size_t(len(privateKey)),
+		ptr(tmp), C.size_t(len(tmp)),
+	)
+	runtime.KeepAlive(expanded)
+	runtime.KeepAlive(privateKey)
+	runtime.KeepAlive(tmp)
+
+	if result != 0 {
+		return falconError(result)
+	}
+	return nil
+}
+
+// cAlloc returns n bytes of C heap memory, which the Go garbage collector
+// never moves or scans; release it with cFree
+func cAlloc(n int) []byte {
+	return unsafe.Slice((*byte)(C.malloc(C.size_t(n))), n)
+}
+
+// cFree releases memory obtained from cAlloc
+func cFree(b []byte) {
+	C.free(ptr(b))
+}
 
+// Sign generates a signature for the given message using the private key
+func Sign(message []byte, privateKey PrivateKey, sigType SignatureType) ([]byte, error) {
 	// Initialize PRNG
-	rng := &PRNGContext{}
-	if err := rng.InitFromSystem(); err != nil {
+	rng, err := newSystemPRNG()
+	if err != nil {
 		return nil, fmt.Errorf("failed to initialize RNG: %w", err)
 	}
 
+	return signWithPRNG(rng, message, privateKey, int(sigType))
+}
+
+// SignWithRNG is Sign drawing randomness from a caller-managed rng
+// instead of seeding a fresh one from the system for every call, which
+// saves the seeding syscall in high-volume loops. rng must already be
+// in output mode (seeded with InitFromSeed or InitFromSystem, or
+// flipped) and is advanced by every signature. It is not safe for
+// concurrent use; give each goroutine its own.
+func SignWithRNG(message []byte, privateKey PrivateKey, sigType int, rng *PRNGContext) ([]byte, error) {
+	if rng == nil {
+		return nil, newError(ErrBadArg, "nil PRNG context")
+	}
+	if !rng.flipped {
+		return nil, ErrContextNotFlipped
+	}
+	return signWithPRNG(rng, message, privateKey, sigType)
+}
+
+// signWithPRNG signs message drawing randomness from rng
+func signWithPRNG(rng *PRNGContext, message, privateKey []byte, sigType int) ([]byte, error) {
+	logN, err := GetLogN(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	defer acquire()()
+
+	tmp, release := getScratch(scratchSign, uint(logN))
+	defer release()
+	return signDyn(rng, message, privateKey, sigType, uint(logN), tmp)
+}
+
+// signWithTmp is signWithPRNG for a private key of degree logN using a
+// caller-provided scratch buffer of at least tmpSizeSignDyn(logN) bytes,
+// which is wiped afterwards
+func signWithTmp(rng *PRNGContext, message, privateKey []byte, sigType int, logN uint, tmp []byte) ([]byte, error) {
+	defer acquire()()
+	return signDyn(rng, message, privateKey, sigType, logN, tmp)
+}
+
+// signDyn runs the C signing for a private key of degree logN. The
+// caller must hold an operation slot.
+func signDyn(rng *PRNGContext, message, privateKey []byte, sigType int, logN uint, tmp []byte) ([]byte, error) {
+	sigSize, err := sigBufferSize(logN, sigType)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create buffers
+	signature := make([]byte, sigSize)
+	sigLen := C.size_t(sigSize)
+	tmp = tmp[:tmpSizeSignDyn(logN)]
+	defer SecureZero(tmp)
+
 	result := C.falcon_sign_dyn(
 		&rng.ctx,
-		unsafe.Pointer(&signature[0]), &sigLen, C.int(sigType),
-		unsafe.Pointer(&privateKey[0]), C.size_t(len(privateKey)),
-		unsafe.Pointer(&message[0]), C.size_t(len(message)),
-		unsafe.Pointer(&tmp[0]), C.size_t(len(tmp)),
+		ptr(signature), &sigLen, C.int(sigType),
+		ptr(privateKey), C.size_t(len(privateKey)),
+		ptr(message), C.size_t(len(message)),
+		ptr(tmp), C.size_t(len(tmp)),
+	)
+	runtime.KeepAlive(signature)
+	runtime.KeepAlive(privateKey)
+	runtime.KeepAlive(message)
+	runtime.KeepAlive(tmp)
+
+	if result != 0 {
+		return nil, falconError(result)
+	}
+
+	return signature[:sigLen], nil
+}
+
+// signTree signs message with an expanded private key of degree logN,
+// drawing randomness from rng
+func signTree(rng *PRNGContext, message, expandedKey []byte, logN uint, sigType int) ([]byte, error) {
+	sigSize, err := sigBufferSize(logN, sigType)
+	if err != nil {
+		return nil, err
+	}
+
+	defer acquire()()
+
+	signature := make([]byte, sigSize)
+	sigLen := C.size_t(sigSize)
+	tmp := make([]byte, tmpSizeSignTree(logN))
+	defer SecureZero(tmp)
+
+	result := C.falcon_sign_tree(
+		&rng.ctx,
+		ptr(signature), &sigLen, C.int(sigType),
+		ptr(expandedKey),
+		ptr(message), C.size_t(len(message)),
+		ptr(tmp), C.size_t(len(tmp)),
 	)
+	runtime.KeepAlive(signature)
+	runtime.KeepAlive(expandedKey)
+	runtime.KeepAlive(message)
+	runtime.KeepAlive(tmp)
 
 	if result != 0 {
 		return nil, falconError(result)
@@ -206,21 +369,158 @@ func Sign(message, privateKey []byte, sigType int) ([]byte, error) {
 }
 
 // Verify verifies a signature using the public key
-func Verify(signature, message, publicKey []byte, sigType int) error {
-	logN, err := GetLogN(publicKey)
+//
+// Malformed inputs are rejected before calling into C with errors that
+// match ErrBadFormat and one of ErrBadHeader, ErrBadLength or
+// ErrUnsupportedDegree.
+func Verify(signature, message []byte, publicKey PublicKey, sigType SignatureType) error {
+	logN, err := checkPublicKey(publicKey)
 	if err != nil {
 		return fmt.Errorf("invalid public key: %w", err)
 	}
+	if _, err := checkSignature(signature, int(sigType)); err != nil {
+		return fmt.Errorf("malformed signature: %w", err)
+	}
+
+	return verify(signature, message, publicKey, int(sigType), logN)
+}
+
+// VerifyKnownLogN verifies a signature like Verify, but trusts the
+// caller-supplied degree instead of decoding it from the public key
+// header. Like Verify, it makes no cgo call besides the verification
+// itself. The public key length must match logN exactly.
+func VerifyKnownLogN(signature, message, publicKey []byte, sigType int, logN int) error {
+	if logN < 1 || logN > 10 {
+		return errInvalidLogN
+	}
+	if len(publicKey) != publicKeySize(uint(logN)) {
+		return fmt.Errorf("%w: public key length %d does not match logN %d", ErrBadLength, len(publicKey), logN)
+	}
+	if _, err := checkSignature(signature, sigType); err != nil {
+		return fmt.Errorf("malformed signature: %w", err)
+	}
 
-	tmpSize := tmpSizeVerify(uint(logN))
-	tmp := make([]byte, tmpSize)
+	return verify(signature, message, publicKey, sigType, uint(logN))
+}
+
+// verify runs the C verification with a scratch buffer sized for logN
+func verify(signature, message, publicKey []byte, sigType int, logN uint) error {
+	defer acquire()()
+
+	tmp, release := getScratch(scratchVerify, logN)
+	defer release()
+	return verifyDyn(signature, message, publicKey, sigType, tmp)
+}
+
+// verifyWithTmp runs the C verification using the caller's scratch
+// buffer, which must hold at least tmpSizeVerify(logN) bytes
+func verifyWithTmp(signature, message, publicKey []byte, sigType int, tmp []byte) error {
+	defer acquire()()
+	return verifyDyn(signature, message, publicKey, sigType, tmp)
+}
 
+// verifyDyn runs the C verification. The caller must hold an operation
+// slot.
+func verifyDyn(signature, message, publicKey []byte, sigType int, tmp []byte) error {
 	result := C.falcon_verify(
-		unsafe.Pointer(&signature[0]), C.size_t(len(signature)), C.int(sigType),
-		unsafe.Pointer(&publicKey[0]), C.size_t(len(publicKey)),
-		unsafe.Pointer(&message[0]), C.size_t(len(message)),
-		unsafe.Pointer(&tmp[0]), C.size_t(len(tmp)),
+		ptr(signature), C.size_t(len(signature)), C.int(sigType),
+		ptr(publicKey), C.size_t(len(publicKey)),
+		ptr(message), C.size_t(len(message)),
+		ptr(tmp), C.size_t(len(tmp)),
+	)
+	runtime.KeepAlive(signature)
+	runtime.KeepAlive(publicKey)
+	runtime.KeepAlive(message)
+	runtime.KeepAlive(tmp)
+
+	if result != 0 {
+		return falconError(result)
+	}
+
+	return nil
+}
+
+// signStart draws a fresh nonce from rng and initializes hashData with
+// it for a streamed signature; the message is then injected by the caller
+func signStart(rng *PRNGContext, hashData *PRNGContext) []byte {
+	nonce := make([]byte, nonceSize)
+	C.falcon_sign_start(&rng.ctx, ptr(nonce), &hashData.ctx)
+	runtime.KeepAlive(nonce)
+	return nonce
+}
+
+// signFinish completes a streamed signature; hashData must have absorbed
+// the nonce and the whole message and still be in input mode
+func signFinish(rng *PRNGContext, privateKey []byte, sigType int, hashData *PRNGContext, nonce []byte) ([]byte, error) {
+	logN, err := GetLogN(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	sigSize, err := sigBufferSize(uint(logN), sigType)
+	if err != nil {
+		return nil, err
+	}
+
+	defer acquire()()
+
+	signature := make([]byte, sigSize)
+	sigLen := C.size_t(sigSize)
+	tmp, release := getScratch(scratchSign, uint(logN))
+	defer release()
+
+	result := C.falcon_sign_dyn_finish(
+		&rng.ctx,
+		ptr(signature), &sigLen, C.int(sigType),
+		ptr(privateKey), C.size_t(len(privateKey)),
+		&hashData.ctx, ptr(nonce),
+		ptr(tmp), C.size_t(len(tmp)),
+	)
+	runtime.KeepAlive(signature)
+	runtime.KeepAlive(privateKey)
+	runtime.KeepAlive(nonce)
+	runtime.KeepAlive(tmp)
+
+	if result != 0 {
+		return nil, falconError(result)
+	}
+
+	return signature[:sigLen], nil
+}
+
+// verifyStart initializes hashData for a streamed verification and
+// injects the nonce taken from the signature
+func verifyStart(hashData *PRNGContext, signature []byte) error {
+	result := C.falcon_verify_start(&hashData.ctx, ptr(signature), C.size_t(len(signature)))
+	runtime.KeepAlive(signature)
+	if result != 0 {
+		return falconError(result)
+	}
+	return nil
+}
+
+// verifyFinish completes a streamed verification; hashData must have
+// absorbed the nonce and the whole message and still be in input mode
+func verifyFinish(signature, publicKey []byte, sigType int, hashData *PRNGContext) error {
+	logN, err := GetLogN(publicKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+
+	defer acquire()()
+
+	tmp, release := getScratch(scratchVerify, uint(logN))
+	defer release()
+
+	result := C.falcon_verify_finish(
+		ptr(signature), C.size_t(len(signature)), C.int(sigType),
+		ptr(publicKey), C.size_t(len(publicKey)),
+		&hashData.ctx,
+		ptr(tmp), C.size_t(len(tmp)),
 	)
+	runtime.KeepAlive(signature)
+	runtime.KeepAlive(publicKey)
+	runtime.KeepAlive(tmp)
 
 	if result != 0 {
 		return falconError(result)
@@ -230,13 +530,26 @@ func Verify(signature, message, publicKey []byte, sigType int) error {
 }
 
 // PRNGContext wraps the C prng_context struct
+//
+// Small Extract calls are served from an output buffer refilled one
+// block at a time, so the byte stream seen through Extract does not
+// depend on how it is split. C operations that draw from the context
+// directly, such as signing with it, continue after any bytes held in
+// that buffer.
 type PRNGContext struct {
-	ctx C.prng_context
+	ctx     C.prng_context
+	flipped bool // in output mode; tracked for Read and Write
+
+	buf    [prngBufferSize]byte
+	bufPos int // next unread byte in buf
+	bufLen int // number of valid bytes in buf
 }
 
 // PRNGContext methods
 func (p *PRNGContext) Init() {
 	C.prng_init(&p.ctx)
+	p.flipped = false
+	p.resetBuffer()
 }
 
 func (p *PRNGContext) InitFromSystem() error {
@@ -244,42 +557,64 @@ func (p *PRNGContext) InitFromSystem() error {
 	if result != 0 {
 		return falconError(result)
 	}
+	p.flipped = true
+	p.resetBuffer()
 	return nil
 }
 
 func (p *PRNGContext) InitFromSeed(seed []byte) {
-	C.prng_init_prng_from_seed(&p.ctx, unsafe.Pointer(&seed[0]), C.size_t(len(seed)))
+	C.prng_init_prng_from_seed(&p.ctx, ptr(seed), C.size_t(len(seed)))
+	runtime.KeepAlive(seed)
+	p.flipped = true
+	p.resetBuffer()
 }
 
 func (p *PRNGContext) Inject(data []byte) {
-	C.prng_inject(&p.ctx, unsafe.Pointer(&data[0]), C.size_t(len(data)))
+	C.prng_inject(&p.ctx, ptr(data), C.size_t(len(data)))
+	runtime.KeepAlive(data)
 }
 
 func (p *PRNGContext) Flip() {
 	C.prng_flip(&p.ctx)
+	p.flipped = true
 }
 
 func (p *PRNGContext) Extract(out []byte) {
-	C.prng_extract(&p.ctx, unsafe.Pointer(&out[0]), C.size_t(len(out)))
+	n := copy(out, p.buf[p.bufPos:p.bufLen])
+	p.bufPos += n
+	out = out[n:]
+	if len(out) == 0 {
+		return
+	}
+
+	// Large requests bypass the buffer; the remainder is refilled
+	if len(out) >= prngBufferSize {
+		C.prng_extract(&p.ctx, ptr(out), C.size_t(len(out)))
+		runtime.KeepAlive(out)
+		return
+	}
+	C.prng_extract(&p.ctx, unsafe.Pointer(&p.buf[0]), C.size_t(prngBufferSize))
+	p.bufPos = copy(out, p.buf[:])
+	p.bufLen = prngBufferSize
 }
 
 // Helper function to convert Falcon error codes to Go errors
 func falconError(code C.int) error {
 	switch code {
 	case ErrRandom:
-		return errors.New("random number generation failed")
+		return ErrRandomFailed
 	case ErrSize:
-		return errors.New("buffer too small")
+		return ErrBufferTooSmall
 	case ErrFormat:
-		return errors.New("invalid format")
+		return ErrInvalidFormat
 	case ErrBadSig:
-		return errors.New("invalid signature")
+		return ErrBadSignature
 	case ErrBadArg:
-		return errors.New("invalid argument")
+		return ErrBadArgument
 	case ErrInternal:
-		return errors.New("internal error")
+		return ErrInternalFailure
 	default:
-		return fmt.Errorf("unknown error: %d", code)
+		return newError(int(code), fmt.Sprintf("unknown error: %d", code))
 	}
 }
 
@@ -0,0 +1,29 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestBuildWithoutCgo builds the package and runs its stub tests with
+// CGO_ENABLED=0, so the !cgo fallback cannot silently stop compiling
+func TestBuildWithoutCgo(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs the go tool")
+	}
+	goTool := filepath.Join(runtime.GOROOT(), "bin", "go")
+	if _, err := os.Stat(goTool); err != nil {
+		t.Skipf("go tool not available: %v", err)
+	}
+
+	cmd := exec.Command(goTool, "test", "-count=1", ".")
+	cmd.Env = append(os.Environ(), "CGO_ENABLED=0")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Package does not build or pass without cgo: %v\n%s", err, out)
+	}
+}
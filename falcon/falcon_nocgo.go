@@ -0,0 +1,224 @@
+//go:build !cgo
+
+package falcon
+
+// This file stands in for falcon.go and inner.go when cgo is disabled,
+// so that packages importing falcon still compile on platforms without
+// a C toolchain. Everything that needs the C library fails with
+// ErrUnsupportedPlatform. The SHAKE256 PRNG is ported to Go (see
+// keccak_nocgo.go), so hashing, header parsing and size computations
+// keep working; only seeding from the system RNG is unavailable.
+
+// Error codes from Falcon, as defined in falcon.h
+const (
+	ErrRandom   = -1 // OS RNG failure
+	ErrSize     = -2 // Buffer too small
+	ErrFormat   = -3 // Invalid format
+	ErrBadSig   = -4 // Invalid signature
+	ErrBadArg   = -5 // Invalid argument
+	ErrInternal = -6 // Internal error
+)
+
+// Signature types, as defined in falcon.h
+const (
+	SigCompressed = 1
+	SigPadded     = 2
+	SigCT         = 3
+)
+
+// Scratch sizes, transcribed from the FALCON_TMPSIZE_* macros in
+// falcon.h
+func tmpSizeKeygen(logN uint) int {
+	n := 28 << logN
+	if logN <= 3 {
+		n = 272
+	}
+	return n + 3<<logN + 7
+}
+
+func tmpSizeMakePub(logN uint) int    { return 6<<logN + 1 }
+func tmpSizeSignDyn(logN uint) int    { return 78<<logN + 7 }
+func tmpSizeVerify(logN uint) int     { return 8<<logN + 1 }
+func tmpSizeSignTree(logN uint) int   { return 50<<logN + 7 }
+func tmpSizeExpandPriv(logN uint) int { return 52<<logN + 7 }
+func expandedKeySize(logN uint) int   { return int((8*logN+40)<<logN) + 8 }
+
+// GetLogN returns ErrUnsupportedPlatform
+func GetLogN(data []byte) (int, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+// GenerateKeyPair returns ErrUnsupportedPlatform
+func GenerateKeyPair(logN uint) (*KeyPair, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func keygen(rng *PRNGContext, logN uint) (*KeyPair, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func derivePublicKey(privateKey []byte) ([]byte, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func expandPrivateKey(privateKey []byte) ([]byte, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func expandPrivateKeyInto(expanded, privateKey []byte, logN uint) error {
+	return ErrUnsupportedPlatform
+}
+
+// Without cgo there is no C heap; ExpandPrivateKey fails before the
+// memory would be used
+func cAlloc(n int) []byte { return make([]byte, n) }
+func cFree(b []byte)      {}
+
+// Sign returns ErrUnsupportedPlatform
+func Sign(message []byte, privateKey PrivateKey, sigType SignatureType) ([]byte, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// SignWithRNG returns ErrUnsupportedPlatform
+func SignWithRNG(message []byte, privateKey PrivateKey, sigType int, rng *PRNGContext) ([]byte, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func signWithPRNG(rng *PRNGContext, message, privateKey []byte, sigType int) ([]byte, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func signWithTmp(rng *PRNGContext, message, privateKey []byte, sigType int, logN uint, tmp []byte) ([]byte, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func signTree(rng *PRNGContext, message, expandedKey []byte, logN uint, sigType int) ([]byte, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// Verify returns ErrUnsupportedPlatform
+func Verify(signature, message []byte, publicKey PublicKey, sigType SignatureType) error {
+	return ErrUnsupportedPlatform
+}
+
+// VerifyKnownLogN returns ErrUnsupportedPlatform
+func VerifyKnownLogN(signature, message, publicKey []byte, sigType int, logN int) error {
+	return ErrUnsupportedPlatform
+}
+
+func verify(signature, message, publicKey []byte, sigType int, logN uint) error {
+	return ErrUnsupportedPlatform
+}
+
+func verifyWithTmp(signature, message, publicKey []byte, sigType int, tmp []byte) error {
+	return ErrUnsupportedPlatform
+}
+
+func signStart(rng *PRNGContext, hashData *PRNGContext) []byte {
+	nonce := make([]byte, nonceSize)
+	rng.Extract(nonce)
+	hashData.Init()
+	hashData.Inject(nonce)
+	return nonce
+}
+
+func signFinish(rng *PRNGContext, privateKey []byte, sigType int, hashData *PRNGContext, nonce []byte) ([]byte, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func verifyStart(hashData *PRNGContext, signature []byte) error {
+	return ErrUnsupportedPlatform
+}
+
+func verifyFinish(signature, publicKey []byte, sigType int, hashData *PRNGContext) error {
+	return ErrUnsupportedPlatform
+}
+
+// PRNGContext is the SHAKE256-based PRNG of the C library, implemented
+// in Go. InitFromSystem returns ErrUnsupportedPlatform.
+type PRNGContext struct {
+	state   shakeState
+	flipped bool
+
+	buf    [prngBufferSize]byte
+	bufPos int
+	bufLen int
+}
+
+func (p *PRNGContext) Init() {
+	p.state.reset()
+	p.flipped = false
+	p.resetBuffer()
+}
+
+func (p *PRNGContext) InitFromSystem() error {
+	return ErrUnsupportedPlatform
+}
+
+func (p *PRNGContext) InitFromSeed(seed []byte) {
+	p.Init()
+	p.Inject(seed)
+	p.Flip()
+}
+
+func (p *PRNGContext) Inject(data []byte) {
+	p.state.absorb(data)
+}
+
+func (p *PRNGContext) Flip() {
+	p.state.pad()
+	p.flipped = true
+}
+
+// Extract needs no output buffer here: the sponge squeezes any length
+// directly
+func (p *PRNGContext) Extract(out []byte) {
+	p.state.squeeze(out)
+}
+
+func falconError(code int) error {
+	switch code {
+	case ErrRandom:
+		return ErrRandomFailed
+	case ErrSize:
+		return ErrBufferTooSmall
+	case ErrFormat:
+		return ErrInvalidFormat
+	case ErrBadSig:
+		return ErrBadSignature
+	case ErrBadArg:
+		return ErrBadArgument
+	case ErrInternal:
+		return ErrInternalFailure
+	default:
+		return newError(code, "unknown error")
+	}
+}
+
+func getPRNGName() string {
+	return "unavailable"
+}
+
+func checkCodec() error {
+	return ErrUnsupportedPlatform
+}
+
+func decodeSigValue(body []byte, logN uint, ct bool) ([]int16, int) {
+	return make([]int16, 1<<logN), 0
+}
+
+func encodeSigValue(out []byte, value []int16, logN uint, ct bool) int {
+	return 0
+}
+
+func decodeSigBody(body []byte, logN uint, ct bool) int {
+	return 0
+}
+
+func nttPublicKey(publicKey []byte, logN uint) ([]uint16, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func verifyNTT(signature []byte, ct, padded bool, h []uint16, logN uint, hashData *PRNGContext, tmp []byte) error {
+	return ErrUnsupportedPlatform
+}
@@ -0,0 +1,469 @@
+//go:build !cgo
+
+package falcon
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+// stubInputs are well-formed keys and a signature for logN 9, so that
+// calls get past the Go-side checks to the point where C is needed
+type stubInputs struct {
+	pub, priv, sig []byte
+	rng            *PRNGContext
+}
+
+func newStubInputs() *stubInputs {
+	in := &stubInputs{
+		pub:  make([]byte, publicKeySize(9)),
+		priv: make([]byte, privateKeySize(9)),
+		sig:  make([]byte, sigCTSize(9)),
+		rng:  &PRNGContext{},
+	}
+	in.pub[0] = headerPublicKey | 9
+	in.priv[0] = headerPrivateKey | 9
+	in.sig[0] = headerSigCT | 9
+	in.rng.InitFromSeed([]byte("stub seed"))
+	return in
+}
+
+// stubCalls maps every exported function and method, by the name
+// exportedAPI reports for it, to a call returning its error result, or
+// nil for functions that have none. The unsupported set lists the calls
+// that must fail with ErrUnsupportedPlatform; all others must succeed.
+func stubCalls(in *stubInputs) (calls map[string]func() error, unsupported map[string]bool) {
+	msg := []byte("message")
+	pub, priv, sig := in.pub, in.priv, in.sig
+	ctx := context.Background()
+	absorbing := func() *PRNGContext {
+		h := &PRNGContext{}
+		h.Init()
+		h.Inject(msg)
+		return h
+	}
+	seed := make([]byte, 48)
+	kp := &KeyPair{PublicKey: pub, PrivateKey: priv}
+	// Values the constructors cannot produce without cgo
+	signer := &Signer{key: &signerKey{privateKey: priv, publicKey: pub}, sigType: SigCT}
+	precomputed := &PrecomputedKey{logN: 9, publicKey: pub, h: make([]uint16, 512)}
+	expanded := func() *ExpandedKey { return &ExpandedKey{logN: 9, key: make([]byte, expandedKeySize(9))} }
+	logEntry := append([]byte{logTagEntry}, make([]byte, logHashSize)...)
+	logEntry = append(logEntry, byte(len(sig)>>8), byte(len(sig)))
+	logEntry = append(logEntry, sig...)
+	item := VerifyItem{Signature: sig, Message: msg, PublicKey: pub, SigType: SigCT}
+	unsupported = make(map[string]bool)
+	fail := func(names ...string) {
+		for _, name := range names {
+			unsupported[name] = true
+		}
+	}
+
+	calls = map[string]func() error{
+		"Address":       func() error { _, err := Address(pub); return err },
+		"AddressString": func() error { _, err := AddressString(pub); return err },
+		"AsPrivateKey":  func() error { AsPrivateKey(priv); return nil },
+		"AsPublicKey":   func() error { AsPublicKey(pub); return nil },
+		"ConvertSignature": func() error {
+			_, err := ConvertSignature(sig, SigCT, SigCompressed, 9)
+			return err
+		},
+		"DebugDump":                  func() error { DebugDump(sig, msg, pub, SigCT); return nil },
+		"DerivePublicKey":            func() error { _, err := DerivePublicKey(priv); return err },
+		"DetectSigType":              func() error { _, err := DetectSigType(sig); return err },
+		"EnvelopeID":                 func() error { EnvelopeID(msg, nil, pub); return nil },
+		"ErrorCode":                  func() error { ErrorCode(ErrBadArgument); return nil },
+		"EstimateKeygenDuration":     func() error { EstimateKeygenDuration(9); return nil },
+		"ExpandPrivateKey":           func() error { _, err := ExpandPrivateKey(priv); return err },
+		"ExpandedKeyFromSeed":        func() error { _, err := ExpandedKeyFromSeed(9, seed); return err },
+		"FindSignedMessage":          func() error { _, err := FindSignedMessage(sig, pub, SigCT, [][]byte{msg}); return err },
+		"Fingerprint":                func() error { _, err := Fingerprint(pub); return err },
+		"GenerateCorpus":             func() error { _, _, err := GenerateCorpus(seed, 9, 1, SigCT); return err },
+		"GenerateKeyPair":            func() error { _, err := GenerateKeyPair(9); return err },
+		"GenerateKeyPairContext":     func() error { _, err := GenerateKeyPairContext(ctx, 9); return err },
+		"GenerateKeyPairFromSeed":    func() error { _, err := GenerateKeyPairFromSeed(9, seed); return err },
+		"GenerateValidatedKeyPair":   func() error { _, err := GenerateValidatedKeyPair(9); return err },
+		"GetLogN":                    func() error { _, err := GetLogN(pub); return err },
+		"KeyIDFromSignature":         func() error { _, err := KeyIDFromSignature(append([]byte{1, 0xAA}, sig...)); return err },
+		"MarshalKeyPairWithPrivate":  func() error { _, err := MarshalKeyPairWithPrivate(kp); return err },
+		"MarshalPKCS8PrivateKey":     func() error { _, err := MarshalPKCS8PrivateKey(priv); return err },
+		"MarshalPKIXPublicKey":       func() error { _, err := MarshalPKIXPublicKey(pub); return err },
+		"MarshalPrivateKeyPEM":       func() error { _, err := MarshalPrivateKeyPEM(priv); return err },
+		"MarshalPublicKeyPEM":        func() error { _, err := MarshalPublicKeyPEM(pub); return err },
+		"NewMemoryNonceStore":        func() error { NewMemoryNonceStore(); return nil },
+		"NewPRNGFromReader":          func() error { _, err := NewPRNGFromReader(bytes.NewReader(seed), 32); return err },
+		"NewSignWriter":              func() error { _, err := NewSignWriter(priv, SigCT, in.rng); return err },
+		"NewSignatureLog":            func() error { NewSignatureLog(&bytes.Buffer{}); return nil },
+		"NewSignatureScanner":        func() error { NewSignatureScanner(SigCT, 9); return nil },
+		"NewSigner":                  func() error { _, err := NewSigner(priv, SigCT); return err },
+		"NewSignerPool":              func() error { NewSignerPool(9); return nil },
+		"NewVerifyCache":             func() error { NewVerifyCache(1); return nil },
+		"NewVerifyPool":              func() error { NewVerifyPool(1, 1).Close(); return nil },
+		"ParseDebugDump":             func() error { _, _, _, _, err := ParseDebugDump(DebugDump(sig, msg, pub, SigCT)); return err },
+		"ParseSignature":             func() error { _, err := ParseSignature(sig); return err },
+		"ParseSignatureHeader":       func() error { _, _, err := ParseSignatureHeader(sig); return err },
+		"PrecomputePublicKey":        func() error { _, err := PrecomputePublicKey(pub); return err },
+		"PrivateKeyEqual":            func() error { PrivateKeyEqual(priv, priv); return nil },
+		"PrivateKeySize":             func() error { PrivateKeySize(9); return nil },
+		"ProofOfPossession":          func() error { _, err := ProofOfPossession(priv, SigCT); return err },
+		"PublicKeySize":              func() error { PublicKeySize(9); return nil },
+		"RecommendSignatureType":     func() error { RecommendSignatureType("size"); return nil },
+		"SealTransportFrame":         func() error { _, err := SealTransportFrame(msg, sig, seed); return err },
+		"SecureZero":                 func() error { SecureZero(make([]byte, 8)); return nil },
+		"SetMaxConcurrency":          func() error { SetMaxConcurrency(0); return nil },
+		"Sign":                       func() error { _, err := Sign(msg, priv, SigCT); return err },
+		"SignBatch":                  func() error { _, err := SignBatch([][]byte{msg}, priv, SigCT); return err },
+		"SignBatchWithRNG":           func() error { _, err := SignBatchWithRNG([][]byte{msg}, priv, SigCT, in.rng); return err },
+		"SignContext":                func() error { _, err := SignContext(ctx, msg, priv, SigCT); return err },
+		"SignDeterministic":          func() error { _, err := SignDeterministic(msg, priv, SigCT, seed); return err },
+		"SignPrehashed":              func() error { _, err := SignPrehashed(msg, priv, SigCT, &PRNGContext{}); return err },
+		"SignReaderLimited":          func() error { _, err := SignReaderLimited(bytes.NewReader(msg), priv, SigCT, 64); return err },
+		"SignStream":                 func() error { _, err := SignStream(bytes.NewReader(msg), priv, SigCT); return err },
+		"SignStruct":                 func() error { _, err := SignStruct(struct{ A int }{1}, priv, SigCT); return err },
+		"SignTimestamped":            func() error { _, err := SignTimestamped(msg, priv, SigCT, time.Now()); return err },
+		"SignVersioned":              func() error { _, err := SignVersioned(msg, priv, SigCT, 1); return err },
+		"SignWithContext":            func() error { _, err := SignWithContext(msg, []byte("ctx"), priv, SigCT); return err },
+		"SignWithContext256":         func() error { _, err := SignWithContext256(absorbing(), priv, SigCT); return err },
+		"SignWithDomain":             func() error { _, err := SignWithDomain(msg, "domain", priv, SigCT); return err },
+		"SignWithKeyID":              func() error { _, err := SignWithKeyID(msg, priv, SigCT, []byte{0xAA}); return err },
+		"SignWithRNG":                func() error { _, err := SignWithRNG(msg, priv, SigCT, in.rng); return err },
+		"SignWithSeed":               func() error { _, err := SignWithSeed(msg, priv, SigCT, seed); return err },
+		"SignWithTree":               func() error { _, err := SignWithTree(msg, expanded(), SigCT, in.rng); return err },
+		"SignatureEqual":             func() error { SignatureEqual(sig, sig); return nil },
+		"SignatureLen":               func() error { SignatureLen(sig); return nil },
+		"SignatureMaxLen":            func() error { SignatureMaxLen(9); return nil },
+		"SignatureSize":              func() error { _, err := SignatureSize(9, SigCT); return err },
+		"SignaturesCoverSameMessage": func() error { _, err := SignaturesCoverSameMessage(sig, sig, msg, pub, SigCT); return err },
+		"Sizes":                      func() error { _, err := Sizes(9); return err },
+		"TestKeys":                   func() error { _, err := TestKeys(seed); return err },
+		"UnmarshalPrivateKeyPEM":     func() error { _, err := UnmarshalPrivateKeyPEM(nil); return ignoreInputErr(err) },
+		"UnmarshalPublicKeyPEM":      func() error { _, err := UnmarshalPublicKeyPEM(nil); return ignoreInputErr(err) },
+		"ValidateKeyPair":            func() error { return ValidateKeyPair(pub, priv) },
+		"ValidatePrivateKey":         func() error { return ValidatePrivateKey(priv) },
+		"ValidatePublicKey":          func() error { return ValidatePublicKey(pub) },
+		"ValidateSignatureStructure": func() error { return ValidateSignatureStructure(sig, SigCT) },
+		"Verify":                     func() error { return Verify(sig, msg, pub, SigCT) },
+		"VerifyAuto":                 func() error { return VerifyAuto(sig, msg, pub) },
+		"VerifyBatch":                func() error { return VerifyBatch([]VerifyItem{item})[0] },
+		"VerifyBatchSameKey":         func() error { return VerifyBatchSameKey([][]byte{sig}, [][]byte{msg}, pub, SigCT)[0] },
+		"VerifyFromSeed":             func() error { return VerifyFromSeed(sig, msg, seed, 9, SigCT) },
+		"VerifyKeyPair":              func() error { return VerifyKeyPair(priv, pub) },
+		"VerifyKnownLogN":            func() error { return VerifyKnownLogN(sig, msg, pub, SigCT, 9) },
+		"VerifyNoReplay":             func() error { return VerifyNoReplay(sig, msg, pub, SigCT, NewMemoryNonceStore()) },
+		"VerifyPrehashed":            func() error { return VerifyPrehashed(sig, msg, pub, SigCT, &PRNGContext{}) },
+		"VerifyProofOfPossession":    func() error { return VerifyProofOfPossession(sig, pub, SigCT) },
+		"VerifyReaderLimited":        func() error { return VerifyReaderLimited(sig, bytes.NewReader(msg), pub, SigCT, 64) },
+		"VerifySegments":             func() error { return VerifySegments(bytes.NewReader(append(make([]byte, 16), sig...)), pub, SigCT, 16) },
+		"VerifySignatureLog": func() error {
+			return VerifySignatureLog(bytes.NewReader(logEntry), func(int) []byte { return pub }, SigCT)
+		},
+		"VerifyStream":       func() error { return VerifyStream(sig, bytes.NewReader(msg), pub, SigCT) },
+		"VerifyStrictFormat": func() error { return VerifyStrictFormat(sig, msg, pub, SigCT) },
+		"VerifyStruct":       func() error { return VerifyStruct(sig, struct{ A int }{1}, pub, SigCT) },
+		"VerifyTimed":        func() error { _, err := VerifyTimed(sig, msg, pub, SigCT); return err },
+		"VerifyTimestamped": func() error {
+			return VerifyTimestamped(append(make([]byte, 8), sig...), msg, pub, SigCT, time.Unix(0, 0), time.Hour)
+		},
+		"VerifyVersioned":      func() error { return VerifyVersioned(append([]byte{1}, sig...), msg, pub, SigCT, []byte{1}) },
+		"VerifyWithContext":    func() error { return VerifyWithContext(sig, msg, []byte("ctx"), pub, SigCT) },
+		"VerifyWithContext256": func() error { return VerifyWithContext256(sig, absorbing(), pub, SigCT) },
+		"VerifyWithDigestFunc": func() error {
+			return VerifyWithDigestFunc(sig, pub, SigCT, func(absorb func([]byte)) error { absorb(msg); return nil })
+		},
+		"VerifyWithDomain": func() error { return VerifyWithDomain(sig, msg, "domain", pub, SigCT) },
+		"VerifyWithKeyID": func() error {
+			return VerifyWithKeyID(append([]byte{1, 0xAA}, sig...), msg, SigCT, func([]byte) ([]byte, error) { return pub, nil })
+		},
+		"VerifyWithPolicy": func() error {
+			return VerifyWithPolicy(sig, msg, pub, SigCT, func(int, int, []byte) error { return nil })
+		},
+		"VerifyWithTransportMAC":       func() error { return VerifyWithTransportMAC(mustSeal(msg, sig, seed), pub, seed, SigCT) },
+		"ParsePKCS8PrivateKey":         func() error { der, _ := MarshalPKCS8PrivateKey(priv); _, err := ParsePKCS8PrivateKey(der); return err },
+		"ParsePKIXPublicKey":           func() error { der, _ := MarshalPKIXPublicKey(pub); _, err := ParsePKIXPublicKey(der); return err },
+		"ExpandedKey.Destroy":          func() error { expanded().Destroy(); return nil },
+		"ExpandedKey.LogN":             func() error { expanded().LogN(); return nil },
+		"ExpandedKey.MemoryBytes":      func() error { expanded().MemoryBytes(); return nil },
+		"ExpandedKey.SignWith":         func() error { _, err := expanded().SignWith(msg, SigCT); return err },
+		"FalconSignerOpts.HashFunc":    func() error { (&FalconSignerOpts{}).HashFunc(); return nil },
+		"KeyPair.Format":               func() error { _ = kp.String(); return nil },
+		"KeyPair.GoString":             func() error { kp.GoString(); return nil },
+		"KeyPair.LogN":                 func() error { _, err := kp.LogN(); return err },
+		"KeyPair.MarshalBinary":        func() error { _, err := kp.MarshalBinary(); return err },
+		"KeyPair.MarshalJSON":          func() error { _, err := kp.MarshalJSON(); return err },
+		"KeyPair.MarshalPrivateKeyPEM": func() error { kp.MarshalPrivateKeyPEM(); return nil },
+		"KeyPair.MarshalPublicKeyPEM":  func() error { kp.MarshalPublicKeyPEM(); return nil },
+		"KeyPair.String":               func() error { _ = kp.String(); return nil },
+		"KeyPair.UnmarshalBinary": func() error {
+			data, _ := kp.MarshalBinary()
+			return (&KeyPair{}).UnmarshalBinary(data)
+		},
+		"KeyPair.UnmarshalJSON": func() error {
+			data, _ := MarshalKeyPairWithPrivate(kp)
+			return (&KeyPair{}).UnmarshalJSON(data)
+		},
+		"KeyPair.Zero":               func() error { (&KeyPair{PublicKey: pub, PrivateKey: append([]byte{}, priv...)}).Zero(); return nil },
+		"MemoryNonceStore.Add":       func() error { NewMemoryNonceStore().Add(msg); return nil },
+		"MemoryNonceStore.Has":       func() error { NewMemoryNonceStore().Has(msg); return nil },
+		"PRNGContext.Extract":        func() error { in.rng.Extract(make([]byte, 8)); return nil },
+		"PRNGContext.Flip":           func() error { absorbing().Flip(); return nil },
+		"PRNGContext.Init":           func() error { (&PRNGContext{}).Init(); return nil },
+		"PRNGContext.InitFromSeed":   func() error { (&PRNGContext{}).InitFromSeed(seed); return nil },
+		"PRNGContext.InitFromSystem": func() error { return (&PRNGContext{}).InitFromSystem() },
+		"PRNGContext.Inject":         func() error { absorbing().Inject(msg); return nil },
+		"PRNGContext.Read":           func() error { _, err := in.rng.Read(make([]byte, 8)); return err },
+		"PRNGContext.Write":          func() error { _, err := absorbing().Write(msg); return err },
+		"PrecomputedKey.PublicKey":   func() error { precomputed.PublicKey(); return nil },
+		"PrecomputedKey.Verify":      func() error { return precomputed.Verify(sig, msg, SigCT) },
+		"PrivateKey.Bytes":           func() error { PrivateKey(priv).Bytes(); return nil },
+		"PrivateKey.Equal":           func() error { PrivateKey(priv).Equal(PrivateKey(priv)); return nil },
+		"PrivateKey.LogN":            func() error { _, err := PrivateKey(priv).LogN(); return err },
+		"PrivateKey.Public":          func() error { PrivateKey(priv).Public(); return nil },
+		"PrivateKey.Sign":            func() error { _, err := PrivateKey(priv).Sign(nil, msg, &FalconSignerOpts{}); return err },
+		"PublicKey.Bytes":            func() error { PublicKey(pub).Bytes(); return nil },
+		"PublicKey.Equal":            func() error { PublicKey(pub).Equal(PublicKey(pub)); return nil },
+		"PublicKey.LogN":             func() error { _, err := PublicKey(pub).LogN(); return err },
+		"SignWriter.Close": func() error {
+			w, err := NewSignWriter(priv, SigCT, in.rng)
+			if err != nil {
+				return err
+			}
+			return w.Close()
+		},
+		"SignWriter.Signature": func() error { _, err := (&SignWriter{}).Signature(); return ignoreInputErr(err) },
+		"SignWriter.Write": func() error {
+			w, err := NewSignWriter(priv, SigCT, in.rng)
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(msg)
+			return err
+		},
+		"Signature.Bytes":       func() error { mustParse(sig).Bytes(); return nil },
+		"Signature.LogN":        func() error { mustParse(sig).LogN(); return nil },
+		"Signature.MarshalJSON": func() error { _, err := mustParse(sig).MarshalJSON(); return err },
+		"Signature.Size":        func() error { mustParse(sig).Size(); return nil },
+		"Signature.String":      func() error { _ = mustParse(sig).String(); return nil },
+		"Signature.Type":        func() error { mustParse(sig).Type(); return nil },
+		"Signature.UnmarshalJSON": func() error {
+			data, _ := mustParse(sig).MarshalJSON()
+			return (&Signature{}).UnmarshalJSON(data)
+		},
+		"Signature.Verify":           func() error { return mustParse(sig).Verify(msg, pub) },
+		"SignatureLog.Append":        func() error { return NewSignatureLog(&bytes.Buffer{}).Append(msg, priv, SigCT) },
+		"SignatureLog.Seal":          func() error { _, err := NewSignatureLog(&bytes.Buffer{}).Seal(priv, SigCT); return err },
+		"SignatureScanner.Complete":  func() error { _, err := NewSignatureScanner(SigCT, 9).Complete(); return err },
+		"SignatureScanner.Signature": func() error { NewSignatureScanner(SigCT, 9).Signature(); return nil },
+		"SignatureScanner.Write":     func() error { _, err := NewSignatureScanner(SigCT, 9).Write(sig); return err },
+		"SignatureType.String":       func() error { _ = SignatureType(SigCT).String(); return nil },
+		"SignatureType.Valid":        func() error { SignatureType(SigCT).Valid(); return nil },
+		"Signer.PublicKey":           func() error { signer.PublicKey(); return nil },
+		"Signer.Rotate":              func() error { return signer.Rotate(priv) },
+		"Signer.Sign":                func() error { _, err := signer.Sign(msg); return err },
+		"SignerPool.Sign":            func() error { _, err := NewSignerPool(9).Sign(msg, priv, SigCT); return err },
+		"SignerPool.Verify":          func() error { return NewSignerPool(9).Verify(sig, msg, pub, SigCT) },
+		"Variant.GenerateKeyPair":    func() error { _, err := Falcon512.GenerateKeyPair(); return err },
+		"Variant.LogN":               func() error { Falcon512.LogN(); return nil },
+		"Variant.Sign":               func() error { _, err := Falcon512.Sign(msg, priv, SigCT); return err },
+		"Variant.String":             func() error { _ = Falcon512.String(); return nil },
+		"Variant.Verify":             func() error { return Falcon512.Verify(sig, msg, pub, SigCT) },
+		"VerifyCache.Len":            func() error { NewVerifyCache(1).Len(); return nil },
+		"VerifyCache.Verify":         func() error { return NewVerifyCache(1).Verify(sig, msg, pub, SigCT) },
+		"VerifyPool.Close":           func() error { NewVerifyPool(1, 1).Close(); return nil },
+		"VerifyPool.Submit": func() error {
+			p := NewVerifyPool(1, 1)
+			defer p.Close()
+			return <-p.Submit(item)
+		},
+	}
+
+	fail("ConvertSignature", "DerivePublicKey", "Fingerprint", "ExpandPrivateKey", "ExpandedKeyFromSeed",
+		"FindSignedMessage", "GenerateCorpus", "GenerateKeyPair", "GenerateKeyPairContext",
+		"GenerateKeyPairFromSeed", "GenerateValidatedKeyPair", "GetLogN",
+		"PrecomputePublicKey", "ProofOfPossession",
+		"Sign", "SignBatch", "SignBatchWithRNG", "SignContext", "SignDeterministic",
+		"SignPrehashed", "SignReaderLimited", "SignStream", "SignStruct", "SignTimestamped",
+		"SignVersioned", "SignWithContext", "SignWithContext256", "SignWithDomain",
+		"SignWithKeyID", "SignWithRNG", "SignWithSeed", "SignWithTree",
+		"SignaturesCoverSameMessage", "TestKeys", "ValidateKeyPair", "Verify", "VerifyAuto", "VerifyBatch", "VerifyBatchSameKey",
+		"VerifyFromSeed", "VerifyKeyPair", "VerifyKnownLogN", "VerifyNoReplay",
+		"VerifyPrehashed", "VerifyProofOfPossession", "VerifyReaderLimited", "VerifySegments",
+		"VerifySignatureLog", "VerifyStream",
+		"VerifyStrictFormat", "VerifyStruct", "VerifyTimed", "VerifyTimestamped",
+		"VerifyVersioned", "VerifyWithContext", "VerifyWithContext256", "VerifyWithDigestFunc",
+		"VerifyWithDomain", "VerifyWithKeyID", "VerifyWithPolicy", "VerifyWithTransportMAC",
+		"NewSigner", "ExpandedKey.SignWith", "PRNGContext.InitFromSystem",
+		"PrecomputedKey.Verify", "PrivateKey.Sign", "SignWriter.Close",
+		"Signature.Verify", "SignatureLog.Append", "SignatureLog.Seal", "Signer.Rotate", "Signer.Sign",
+		"SignatureScanner.Complete", "SignatureScanner.Write", "SignerPool.Sign", "SignerPool.Verify", "Variant.GenerateKeyPair", "Variant.Sign",
+		"Variant.Verify", "VerifyCache.Verify", "VerifyPool.Submit")
+	return calls, unsupported
+}
+
+// ignoreInputErr discards an error a call is expected to report for its empty
+// input; only a panic or ErrUnsupportedPlatform would be a failure
+func ignoreInputErr(err error) error {
+	if errors.Is(err, ErrUnsupportedPlatform) {
+		return err
+	}
+	return nil
+}
+
+func mustParse(sig []byte) *Signature {
+	s, err := ParseSignature(sig)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func mustSeal(message, signature, macKey []byte) []byte {
+	frame, err := SealTransportFrame(message, signature, macKey)
+	if err != nil {
+		panic(err)
+	}
+	return frame
+}
+
+// exportedAPI lists the exported functions and methods compiled into
+// the package without cgo, methods as Type.Method
+func exportedAPI(t *testing.T) []string {
+	bctx := build.Default
+	bctx.CgoEnabled = false
+	pkg, err := bctx.ImportDir(".", 0)
+	if err != nil {
+		t.Fatalf("Failed to list package files: %v", err)
+	}
+	fset := token.NewFileSet()
+	var names []string
+	for _, file := range pkg.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(pkg.Dir, file), nil, 0)
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", file, err)
+		}
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || !fn.Name.IsExported() {
+				continue
+			}
+			name := fn.Name.Name
+			if fn.Recv != nil {
+				typ := fn.Recv.List[0].Type
+				if star, ok := typ.(*ast.StarExpr); ok {
+					typ = star.X
+				}
+				recv := typ.(*ast.Ident)
+				if !recv.IsExported() {
+					continue
+				}
+				name = recv.Name + "." + name
+			}
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestStubsReturnUnsupportedPlatform(t *testing.T) {
+	calls, unsupported := stubCalls(newStubInputs())
+	for _, name := range exportedAPI(t) {
+		if _, ok := calls[name]; !ok {
+			t.Errorf("%s is not covered by stubCalls", name)
+		}
+	}
+
+	for name, call := range calls {
+		name, call := name, call
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Fatalf("panicked: %v", r)
+				}
+			}()
+			err := call()
+			if unsupported[name] {
+				if !errors.Is(err, ErrUnsupportedPlatform) {
+					t.Errorf("expected ErrUnsupportedPlatform, got %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+// TestStubSHAKE256 checks the Go port of the PRNG against SHAKE256 test
+// vectors and values computed by the C library
+func TestStubSHAKE256(t *testing.T) {
+	for _, tc := range []struct {
+		parts [][]byte
+		want  string
+	}{
+		{nil, "46b9dd2b0ba88d13233b3feb743eeb243fcd52ea62b81b82b50c27646ed5762f"},
+		{[][]byte{[]byte("abc")}, "483366601360a8771c6863080cc4114d8db44530f8f1e1ee4f94ea37e78b5739"},
+		// Crosses a block boundary while absorbing
+		{[][]byte{bytes.Repeat([]byte{0xA3}, 100), bytes.Repeat([]byte{0xA3}, 100)},
+			"cd8a920ed141aa0407a22d59288652e9d9f1a7ee0c1e7c1ca699424da84a904d"},
+	} {
+		if got := hex.EncodeToString(shake256(32, tc.parts...)); got != tc.want {
+			t.Errorf("shake256(%x) = %s, want %s", tc.parts, got, tc.want)
+		}
+		if got := hex.EncodeToString(fixedShake256(32, bytes.Join(tc.parts, nil))); got != tc.want {
+			t.Errorf("fixedShake256(%x) = %s, want %s", tc.parts, got, tc.want)
+		}
+	}
+
+	// Squeezing in odd pieces across a block boundary matches one call
+	whole := shake256(300, []byte("abc"))
+	h := &PRNGContext{}
+	h.Init()
+	h.Inject([]byte("abc"))
+	h.Flip()
+	pieces := make([]byte, 300)
+	for off, n := 0, 1; off < len(pieces); off, n = off+n, n+6 {
+		if off+n > len(pieces) {
+			n = len(pieces) - off
+		}
+		h.Extract(pieces[off : off+n])
+	}
+	if !bytes.Equal(whole, pieces) {
+		t.Error("split extraction differs from a single Extract")
+	}
+}
+
+// TestStubSizes checks the Go transcription of the falcon.h size macros
+// against the values the C library reports
+func TestStubSizes(t *testing.T) {
+	want := map[uint][8]int{
+		1:  {7, 5, 44, 44, 44, 285, 163, 17},
+		2:  {13, 8, 47, 47, 47, 291, 319, 33},
+		3:  {25, 15, 52, 52, 52, 303, 631, 65},
+		4:  {49, 29, 64, 63, 65, 503, 1255, 129},
+		5:  {97, 57, 86, 82, 89, 999, 2503, 257},
+		6:  {177, 113, 130, 122, 137, 1991, 4999, 513},
+		7:  {353, 225, 219, 200, 233, 3975, 9991, 1025},
+		8:  {641, 449, 397, 356, 425, 7943, 19975, 2049},
+		9:  {1281, 897, 752, 666, 809, 15879, 39943, 4097},
+		10: {2305, 1793, 1462, 1280, 1577, 31751, 79879, 8193},
+	}
+	for logN, w := range want {
+		s, err := Sizes(logN)
+		if err != nil {
+			t.Fatalf("Sizes(%d) failed: %v", logN, err)
+		}
+		got := [8]int{s.PrivateKey, s.PublicKey, s.SigCompressedMax, s.SigPadded, s.SigCT, s.TmpKeygen, s.TmpSign, s.TmpVerify}
+		if got != w {
+			t.Errorf("Sizes(%d) = %v, want %v", logN, got, w)
+		}
+	}
+}
@@ -1,8 +1,13 @@
+//go:build cgo
+
 package falcon
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"runtime"
+	"runtime/debug"
 	"testing"
 )
 
@@ -41,14 +46,14 @@ func TestFalconSignatureLifecycle(t *testing.T) {
 			t.Logf("Testing signature type: %s", st.name)
 
 			// Generate signature
-			signature, err := Sign(message, keyPair.PrivateKey, st.typ)
+			signature, err := Sign(message, keyPair.PrivateKey, SignatureType(st.typ))
 			if err != nil {
 				t.Fatalf("Failed to sign message: %v", err)
 			}
 			t.Logf("Generated signature size: %d", len(signature))
 
 			// Verify signature
-			err = Verify(signature, message, keyPair.PublicKey, st.typ)
+			err = Verify(signature, message, keyPair.PublicKey, SignatureType(st.typ))
 			if err != nil {
 				t.Fatalf("Signature verification failed: %v", err)
 			}
@@ -124,3 +129,174 @@ func TestGetLogN(t *testing.T) {
 		})
 	}
 }
+
+func TestEmptySlices(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	// Empty messages are legitimate and must round-trip
+	for _, sigType := range []int{SigCompressed, SigPadded, SigCT} {
+		signature, err := Sign(nil, keyPair.PrivateKey, SignatureType(sigType))
+		if err != nil {
+			t.Fatalf("Failed to sign empty message: %v", err)
+		}
+		if err := Verify(signature, []byte{}, keyPair.PublicKey, SignatureType(sigType)); err != nil {
+			t.Fatalf("Failed to verify empty message: %v", err)
+		}
+	}
+
+	message := []byte("message")
+	signature, err := Sign(message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	// Every other signing path passes the empty message to C as a NULL
+	// pointer with zero length too
+	expanded, err := ExpandPrivateKey(keyPair.PrivateKey)
+	if err != nil {
+		t.Fatalf("Failed to expand private key: %v", err)
+	}
+	defer expanded.Destroy()
+	for _, sigType := range []int{SigCompressed, SigPadded, SigCT} {
+		signers := map[string]func() ([]byte, error){
+			"SignWith":          func() ([]byte, error) { return expanded.SignWith(nil, sigType) },
+			"SignDeterministic": func() ([]byte, error) { return SignDeterministic(nil, keyPair.PrivateKey, sigType, make([]byte, 32)) },
+			"SignStream":        func() ([]byte, error) { return SignStream(bytes.NewReader(nil), keyPair.PrivateKey, sigType) },
+		}
+		for name, sign := range signers {
+			sig, err := sign()
+			if err != nil {
+				t.Fatalf("%s: failed to sign empty message: %v", name, err)
+			}
+			if err := Verify(sig, nil, keyPair.PublicKey, SignatureType(sigType)); err != nil {
+				t.Fatalf("%s: failed to verify empty message: %v", name, err)
+			}
+		}
+	}
+
+	// Empty keys and signatures must fail cleanly rather than panic
+	if _, err := Sign(message, nil, SigCompressed); err == nil {
+		t.Error("Expected error for empty private key")
+	}
+	if err := Verify(nil, message, keyPair.PublicKey, SigCompressed); err == nil {
+		t.Error("Expected error for empty signature")
+	}
+	if err := Verify(signature, message, nil, SigCompressed); err == nil {
+		t.Error("Expected error for empty public key")
+	}
+	if err := VerifyKnownLogN(nil, message, keyPair.PublicKey, SigCompressed, 9); err == nil {
+		t.Error("Expected error for empty signature with known logN")
+	}
+	if _, err := GetLogN(nil); err == nil {
+		t.Error("Expected error for empty GetLogN input")
+	}
+	if _, err := derivePublicKey(nil); err == nil {
+		t.Error("Expected error for empty private key in derivation")
+	}
+	feed := func(absorb func([]byte)) error {
+		absorb(nil)
+		return nil
+	}
+	if err := VerifyWithDigestFunc(nil, keyPair.PublicKey, SigCompressed, feed); err == nil {
+		t.Error("Expected error for empty streamed signature")
+	}
+
+	// PRNG operations accept empty buffers
+	ctx := &PRNGContext{}
+	ctx.InitFromSeed(nil)
+	ctx.Init()
+	ctx.Inject(nil)
+	ctx.Inject([]byte{})
+	ctx.Flip()
+	ctx.Extract(nil)
+	ctx.Extract([]byte{})
+}
+
+func TestSignWithRNG(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	rng := &PRNGContext{}
+	rng.InitFromSeed(bytes.Repeat([]byte{0x33}, 32))
+
+	var previous []byte
+	for i := 0; i < 5; i++ {
+		message := []byte(fmt.Sprintf("message %d", i))
+		signature, err := SignWithRNG(message, keyPair.PrivateKey, SigCT, rng)
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		if err := Verify(signature, message, keyPair.PublicKey, SigCT); err != nil {
+			t.Fatalf("Signature verification failed: %v", err)
+		}
+		if bytes.Equal(signature[1:1+nonceSize], previous) {
+			t.Fatal("Reused PRNG repeated a nonce")
+		}
+		previous = signature[1 : 1+nonceSize]
+	}
+
+	unflipped := &PRNGContext{}
+	unflipped.Init()
+	if _, err := SignWithRNG([]byte("m"), keyPair.PrivateKey, SigCT, unflipped); !errors.Is(err, ErrContextNotFlipped) {
+		t.Fatalf("Expected ErrContextNotFlipped, got %v", err)
+	}
+	if _, err := SignWithRNG([]byte("m"), keyPair.PrivateKey, SigCT, nil); !errors.Is(err, ErrBadArgument) {
+		t.Fatalf("Expected ErrBadArgument for a nil context, got %v", err)
+	}
+}
+
+// TestCgoUnderGCPressure signs and verifies in a tight loop while the
+// collector runs constantly, so that a slice freed while C still reads
+// it shows up as a failed verification or a crash
+func TestCgoUnderGCPressure(t *testing.T) {
+	iterations := 200
+	if testing.Short() {
+		iterations = 20
+	}
+	defer debug.SetGCPercent(debug.SetGCPercent(1))
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				runtime.GC()
+			}
+		}
+	}()
+	defer func() {
+		close(stop)
+		<-done
+	}()
+
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	for i := 0; i < iterations; i++ {
+		// Fresh copies that are unreachable from Go once passed to C
+		message := []byte(fmt.Sprintf("gc pressure %d", i))
+		priv := append([]byte{}, keyPair.PrivateKey...)
+		sigType := []int{SigCompressed, SigPadded, SigCT}[i%3]
+
+		signature, err := Sign(message, priv, SignatureType(sigType))
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		runtime.GC()
+		if err := Verify(signature, append([]byte{}, message...), append([]byte{}, keyPair.PublicKey...), SignatureType(sigType)); err != nil {
+			t.Fatalf("Iteration %d: signature verification failed: %v", i, err)
+		}
+		if _, err := GetLogN(append([]byte{}, signature...)); err != nil {
+			t.Fatalf("Failed to get logN: %v", err)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package falcon
+
+import "fmt"
+
+// nonceSize is the length of the signature nonce
+const nonceSize = 40
+
+// Format errors. Every malformed-input error returned by this package,
+// including FALCON_ERR_FORMAT from the C library, matches ErrBadFormat
+// with errors.Is and reports ErrFormat from ErrorCode; the sub-errors
+// narrow down the cause. ErrBadFormat is the same value as
+// ErrInvalidFormat.
+var (
+	ErrBadFormat         = ErrInvalidFormat
+	ErrBadHeader         = fmt.Errorf("%w: bad header", ErrBadFormat)
+	ErrBadLength         = fmt.Errorf("%w: bad length", ErrBadFormat)
+	ErrUnsupportedDegree = fmt.Errorf("%w: unsupported degree", ErrBadFormat)
+)
+
+// Header byte layout: the high nibble identifies the object, the low
+// nibble holds logN
+const (
+	headerPublicKey     = 0x00
+	headerPrivateKey    = 0x50
+	headerSigCompressed = 0x30 // compressed and padded signatures
+	headerSigCT         = 0x50
+)
+
+// headerLogN extracts and range-checks the degree from a header byte
+func headerLogN(header byte) (uint, error) {
+	logN := uint(header & 0x0F)
+	if logN < 1 || logN > 10 {
+		return 0, fmt.Errorf("%w: logN %d", ErrUnsupportedDegree, logN)
+	}
+	return logN, nil
+}
+
+// checkPublicKey validates the header and length of an encoded public
+// key without calling into C, and returns its degree
+func checkPublicKey(publicKey []byte) (uint, error) {
+	if len(publicKey) == 0 {
+		return 0, fmt.Errorf("%w: empty public key", ErrBadLength)
+	}
+	if publicKey[0]&0xF0 != headerPublicKey {
+		return 0, fmt.Errorf("%w: 0x%02x is not a public key header", ErrBadHeader, publicKey[0])
+	}
+	logN, err := headerLogN(publicKey[0])
+	if err != nil {
+		return 0, err
+	}
+	if want := publicKeySize(logN); len(publicKey) != want {
+		return 0, fmt.Errorf("%w: public key is %d bytes, want %d", ErrBadLength, len(publicKey), want)
+	}
+	return logN, nil
+}
+
+// checkSignature validates the header and length of an encoded signature
+// against the expected type without calling into C, and returns its
+// degree. A sigType of 0 accepts any type, as in the C library.
+func checkSignature(signature []byte, sigType int) (uint, error) {
+	if len(signature) == 0 {
+		return 0, fmt.Errorf("%w: empty signature", ErrBadLength)
+	}
+	logN, err := headerLogN(signature[0])
+	if err != nil {
+		return 0, err
+	}
+	if len(signature) < 1+nonceSize+1 {
+		return 0, fmt.Errorf("%w: signature is %d bytes, too short for header and nonce", ErrBadLength, len(signature))
+	}
+
+	high := signature[0] & 0xF0
+	if sigType == 0 {
+		switch high {
+		case headerSigCompressed:
+			sigType = SigCompressed
+		case headerSigCT:
+			sigType = SigCT
+		default:
+			return 0, fmt.Errorf("%w: 0x%02x is not a signature header", ErrBadHeader, signature[0])
+		}
+	}
+
+	var wantHigh byte
+	switch sigType {
+	case SigCompressed, SigPadded:
+		wantHigh = headerSigCompressed
+	case SigCT:
+		wantHigh = headerSigCT
+	default:
+		return 0, errInvalidSigType
+	}
+	if high != wantHigh {
+		return 0, fmt.Errorf("%w: header 0x%02x does not match signature type %d", ErrBadHeader, signature[0], sigType)
+	}
+
+	switch sigType {
+	case SigCompressed:
+		if max := sigCompressedMaxSize(logN); len(signature) > max {
+			return 0, fmt.Errorf("%w: compressed signature is %d bytes, max %d", ErrBadLength, len(signature), max)
+		}
+	case SigPadded:
+		if want := sigPaddedSize(logN); len(signature) != want {
+			return 0, fmt.Errorf("%w: padded signature is %d bytes, want %d", ErrBadLength, len(signature), want)
+		}
+	case SigCT:
+		if want := sigCTSize(logN); len(signature) != want {
+			return 0, fmt.Errorf("%w: CT signature is %d bytes, want %d", ErrBadLength, len(signature), want)
+		}
+	}
+	return logN, nil
+}
+
+// DetectSigType infers the signature type (SigCompressed, SigPadded or
+// SigCT) from the header and length. Malformed signatures yield errors
+// matching ErrBadFormat. Compressed and padded signatures share a
+// header, so a compressed signature that happens to be exactly the
+// padded length is reported as padded; such a signature is valid in both
+// formats, which is the same ambiguity the format itself has.
+func DetectSigType(signature []byte) (int, error) {
+	logN, err := checkSignature(signature, 0)
+	if err != nil {
+		return 0, err
+	}
+	if signature[0]&0xF0 == headerSigCT {
+		return SigCT, nil
+	}
+	if len(signature) == sigPaddedSize(logN) {
+		return SigPadded, nil
+	}
+	return SigCompressed, nil
+}
+
+// ParseSignatureHeader decodes only the first byte of a signature,
+// returning its degree and type, for dispatch code that must pick a key
+// before verifying. Compressed and padded signatures share a header and
+// are both reported as SigCompressed; DetectSigType tells them apart
+// using the length. Errors match ErrBadFormat and, more specifically,
+// ErrBadLength for empty input, ErrBadHeader for an unknown type nibble
+// and ErrUnsupportedDegree for a logN outside 1..10.
+func ParseSignatureHeader(signature []byte) (logN int, sigType int, err error) {
+	if len(signature) == 0 {
+		return 0, 0, fmt.Errorf("%w: empty signature", ErrBadLength)
+	}
+	switch signature[0] & 0xF0 {
+	case headerSigCompressed:
+		sigType = SigCompressed
+	case headerSigCT:
+		sigType = SigCT
+	default:
+		return 0, 0, fmt.Errorf("%w: 0x%02x is not a signature header", ErrBadHeader, signature[0])
+	}
+	n, err := headerLogN(signature[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(n), sigType, nil
+}
+
+// ValidateSignatureStructure performs the cheap structural checks on a
+// signature (header byte, degree, presence of the 40-byte nonce and the
+// length expected for sigType) without running the lattice verification.
+// A nil result does not mean the signature is valid, only that it is
+// worth verifying. Errors match ErrBadFormat.
+func ValidateSignatureStructure(signature []byte, sigType int) error {
+	_, err := checkSignature(signature, sigType)
+	return err
+}
+
+// ValidatePublicKey checks that publicKey is structurally a Falcon public
+// key: a public key header with a supported degree and the encoded length
+// for that degree. It does not decode the key. Errors match ErrBadFormat.
+func ValidatePublicKey(publicKey []byte) error {
+	_, err := checkPublicKey(publicKey)
+	return err
+}
+
+// ValidatePrivateKey checks that privateKey is structurally a Falcon
+// private key: a private key header with a supported degree and the
+// encoded length for that degree. It does not decode the key. Errors
+// match ErrBadFormat.
+func ValidatePrivateKey(privateKey []byte) error {
+	_, err := checkPrivateKey(privateKey)
+	return err
+}
+
+// checkPrivateKey validates the header and length of an encoded private
+// key without calling into C, and returns its degree
+func checkPrivateKey(privateKey []byte) (uint, error) {
+	if len(privateKey) == 0 {
+		return 0, fmt.Errorf("%w: empty private key", ErrBadLength)
+	}
+	if privateKey[0]&0xF0 != headerPrivateKey {
+		return 0, fmt.Errorf("%w: 0x%02x is not a private key header", ErrBadHeader, privateKey[0])
+	}
+	logN, err := headerLogN(privateKey[0])
+	if err != nil {
+		return 0, err
+	}
+	if want := privateKeySize(logN); len(privateKey) != want {
+		return 0, fmt.Errorf("%w: private key is %d bytes, want %d", ErrBadLength, len(privateKey), want)
+	}
+	return logN, nil
+}
@@ -0,0 +1,208 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVerifyFormatErrors(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	message := []byte("format checks")
+	compressed, err := Sign(message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	ct, err := Sign(message, keyPair.PrivateKey, SigCT)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	withHeader := func(b []byte, header byte) []byte {
+		c := append([]byte{}, b...)
+		c[0] = header
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		sig     []byte
+		pub     []byte
+		sigType int
+		want    error
+	}{
+		{"EmptySignature", nil, keyPair.PublicKey, SigCompressed, ErrBadLength},
+		{"ShortSignature", compressed[:20], keyPair.PublicKey, SigCompressed, ErrBadLength},
+		{"PaddedWrongLength", compressed, keyPair.PublicKey, SigPadded, ErrBadLength},
+		{"TruncatedCT", ct[:len(ct)-1], keyPair.PublicKey, SigCT, ErrBadLength},
+		{"CTHeaderAsCompressed", ct, keyPair.PublicKey, SigCompressed, ErrBadHeader},
+		{"CompressedHeaderAsCT", compressed, keyPair.PublicKey, SigCT, ErrBadHeader},
+		{"UnknownSignatureHeader", withHeader(compressed, 0x79), keyPair.PublicKey, 0, ErrBadHeader},
+		{"SignatureDegreeZero", withHeader(compressed, 0x30), keyPair.PublicKey, SigCompressed, ErrUnsupportedDegree},
+		{"SignatureDegreeEleven", withHeader(compressed, 0x3B), keyPair.PublicKey, SigCompressed, ErrUnsupportedDegree},
+		{"PrivateKeyAsPublicKey", compressed, keyPair.PrivateKey, SigCompressed, ErrBadHeader},
+		{"PublicKeyDegreeEleven", compressed, withHeader(keyPair.PublicKey, 0x0B), SigCompressed, ErrUnsupportedDegree},
+		{"TruncatedPublicKey", compressed, keyPair.PublicKey[:100], SigCompressed, ErrBadLength},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Verify(tc.sig, message, tc.pub, SignatureType(tc.sigType))
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("Expected %v, got %v", tc.want, err)
+			}
+			if !errors.Is(err, ErrBadFormat) {
+				t.Fatalf("Expected error to wrap ErrBadFormat, got %v", err)
+			}
+		})
+	}
+
+	// Format errors reported by the C library map to ErrBadFormat too
+	if err := falconError(ErrFormat); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("Expected C format error to match ErrBadFormat, got %v", err)
+	}
+}
+
+func TestValidateSignatureStructure(t *testing.T) {
+	for _, logN := range []uint{9, 10} {
+		keyPair, err := GenerateKeyPair(logN)
+		if err != nil {
+			t.Fatalf("Failed to generate key pair: %v", err)
+		}
+
+		for _, sigType := range []int{SigCompressed, SigPadded, SigCT} {
+			signature, err := Sign([]byte("structure"), keyPair.PrivateKey, SignatureType(sigType))
+			if err != nil {
+				t.Fatalf("Failed to sign message: %v", err)
+			}
+
+			if err := ValidateSignatureStructure(signature, sigType); err != nil {
+				t.Errorf("logN=%d type=%d: well-formed signature rejected: %v", logN, sigType, err)
+			}
+
+			malformed := map[string][]byte{
+				"Empty":        nil,
+				"NonceOnly":    signature[:1+nonceSize],
+				"BadDegree":    append([]byte{signature[0]&0xF0 | 0x0F}, signature[1:]...),
+				"WrongHeader":  append([]byte{0x70 | signature[0]&0x0F}, signature[1:]...),
+				"TrailingByte": append(append([]byte{}, signature...), make([]byte, sigCompressedMaxSize(logN))...),
+			}
+			for name, sig := range malformed {
+				if err := ValidateSignatureStructure(sig, sigType); !errors.Is(err, ErrBadFormat) {
+					t.Errorf("logN=%d type=%d %s: expected ErrBadFormat, got %v", logN, sigType, name, err)
+				}
+			}
+		}
+	}
+}
+
+func TestValidateKeyStructure(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	if err := ValidatePublicKey(keyPair.PublicKey); err != nil {
+		t.Fatalf("Well-formed public key rejected: %v", err)
+	}
+	if err := ValidatePrivateKey(keyPair.PrivateKey); err != nil {
+		t.Fatalf("Well-formed private key rejected: %v", err)
+	}
+
+	malformed := map[string]struct {
+		validate func([]byte) error
+		key      []byte
+	}{
+		"PublicEmpty":      {ValidatePublicKey, nil},
+		"PublicTruncated":  {ValidatePublicKey, keyPair.PublicKey[:len(keyPair.PublicKey)-1]},
+		"PublicBadDegree":  {ValidatePublicKey, append([]byte{0x0F}, keyPair.PublicKey[1:]...)},
+		"PublicIsPrivate":  {ValidatePublicKey, keyPair.PrivateKey},
+		"PrivateEmpty":     {ValidatePrivateKey, nil},
+		"PrivateTruncated": {ValidatePrivateKey, keyPair.PrivateKey[:len(keyPair.PrivateKey)-1]},
+		"PrivateWrongLogN": {ValidatePrivateKey, append([]byte{0x5A}, keyPair.PrivateKey[1:]...)},
+		"PrivateIsPublic":  {ValidatePrivateKey, keyPair.PublicKey},
+	}
+	for name, tt := range malformed {
+		if err := tt.validate(tt.key); !errors.Is(err, ErrBadFormat) {
+			t.Errorf("%s: expected ErrBadFormat, got %v", name, err)
+		}
+	}
+}
+
+func TestDetectSigType(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	for _, sigType := range []int{SigCompressed, SigPadded, SigCT} {
+		signature, err := Sign([]byte("detect"), keyPair.PrivateKey, SignatureType(sigType))
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		got, err := DetectSigType(signature)
+		if err != nil {
+			t.Fatalf("Failed to detect signature type: %v", err)
+		}
+		if got != sigType {
+			t.Fatalf("DetectSigType = %d, want %d", got, sigType)
+		}
+	}
+
+	for name, sig := range map[string][]byte{
+		"Empty":     nil,
+		"PublicKey": keyPair.PublicKey,
+		"Short":     {0x39, 0x00},
+	} {
+		if _, err := DetectSigType(sig); !errors.Is(err, ErrBadFormat) {
+			t.Errorf("%s: expected ErrBadFormat, got %v", name, err)
+		}
+	}
+}
+
+func TestParseSignatureHeader(t *testing.T) {
+	for _, logN := range []uint{1, 9, 10} {
+		for _, tt := range []struct {
+			header byte
+			want   int
+		}{
+			{headerSigCompressed, SigCompressed},
+			{headerSigCT, SigCT},
+		} {
+			gotLogN, gotType, err := ParseSignatureHeader([]byte{tt.header | byte(logN)})
+			if err != nil {
+				t.Fatalf("Failed to parse header: %v", err)
+			}
+			if gotLogN != int(logN) || gotType != tt.want {
+				t.Fatalf("Header 0x%02x: got logN %d type %d", tt.header|byte(logN), gotLogN, gotType)
+			}
+		}
+	}
+
+	tests := []struct {
+		name string
+		sig  []byte
+		want error
+	}{
+		{"Empty", nil, ErrBadLength},
+		{"UnknownType", []byte{0x79}, ErrBadHeader},
+		{"PublicKeyHeader", []byte{0x09}, ErrBadHeader},
+		{"DegreeZero", []byte{0x30}, ErrUnsupportedDegree},
+		{"DegreeEleven", []byte{0x5B}, ErrUnsupportedDegree},
+	}
+	for _, tt := range tests {
+		_, _, err := ParseSignatureHeader(tt.sig)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+		for _, other := range []error{ErrBadLength, ErrBadHeader, ErrUnsupportedDegree} {
+			if other != tt.want && errors.Is(err, other) {
+				t.Errorf("%s: error %v also matches %v", tt.name, err, other)
+			}
+		}
+	}
+}
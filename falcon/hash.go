@@ -0,0 +1,74 @@
+package falcon
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// FingerprintSize is the length in bytes of a public key fingerprint
+const FingerprintSize = 32
+
+// shake256 absorbs the given parts in order and squeezes outLen bytes.
+// Callers that need unambiguous framing must length-prefix their inputs
+// themselves.
+func shake256(outLen int, parts ...[]byte) []byte {
+	h := &PRNGContext{}
+	h.Init()
+	for _, p := range parts {
+		h.Inject(p)
+	}
+	h.Flip()
+	out := make([]byte, outLen)
+	h.Extract(out)
+	return out
+}
+
+// shake256Framed is like shake256 but prefixes every part with its
+// length as a 64-bit big-endian integer, so distinct part boundaries
+// always produce distinct digests
+func shake256Framed(outLen int, parts ...[]byte) []byte {
+	h := &PRNGContext{}
+	h.Init()
+	injectFramed(h, parts...)
+	h.Flip()
+	out := make([]byte, outLen)
+	h.Extract(out)
+	return out
+}
+
+// injectFramed absorbs each part into h preceded by its length as a
+// 64-bit big-endian integer, so the boundaries between parts are
+// unambiguous
+func injectFramed(h *PRNGContext, parts ...[]byte) {
+	var lenBuf [8]byte
+	for _, p := range parts {
+		binary.BigEndian.PutUint64(lenBuf[:], uint64(len(p)))
+		h.Inject(lenBuf[:])
+		h.Inject(p)
+	}
+}
+
+// Fingerprint returns a short identifier for a public key, computed as
+// SHAKE256 over the encoded key
+func Fingerprint(publicKey []byte) ([]byte, error) {
+	if _, err := GetLogN(publicKey); err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	return shake256(FingerprintSize, publicKey), nil
+}
+
+// EnvelopeIDSize is the length in bytes of an envelope identifier
+const EnvelopeIDSize = 32
+
+// envelopeIDLabel separates EnvelopeID digests from the package's other
+// uses of SHAKE256
+var envelopeIDLabel = []byte("falcon-go envelope id")
+
+// EnvelopeID returns a stable identifier binding message, context and
+// the signer's public key, computed as SHAKE256 over the three inputs,
+// each prefixed with its length. It can index signed items without
+// storing their signatures. The inputs are hashed as given and not
+// validated.
+func EnvelopeID(message, context, publicKey []byte) []byte {
+	return shake256Framed(EnvelopeIDSize, envelopeIDLabel, message, context, publicKey)
+}
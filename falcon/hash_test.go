@@ -0,0 +1,40 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEnvelopeID(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	message, context := []byte("order 17"), []byte("shop")
+	id := EnvelopeID(message, context, keyPair.PublicKey)
+	if len(id) != EnvelopeIDSize {
+		t.Fatalf("Expected %d-byte ID, got %d", EnvelopeIDSize, len(id))
+	}
+	if !bytes.Equal(id, EnvelopeID(message, context, keyPair.PublicKey)) {
+		t.Fatal("Envelope ID is not deterministic")
+	}
+
+	other, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	variants := map[string][]byte{
+		"message":  EnvelopeID([]byte("order 18"), context, keyPair.PublicKey),
+		"context":  EnvelopeID(message, []byte("shop2"), keyPair.PublicKey),
+		"key":      EnvelopeID(message, context, other.PublicKey),
+		"boundary": EnvelopeID([]byte("order 17s"), []byte("hop"), keyPair.PublicKey),
+	}
+	for name, v := range variants {
+		if bytes.Equal(id, v) {
+			t.Fatalf("Changing the %s did not change the ID", name)
+		}
+	}
+}
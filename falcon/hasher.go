@@ -0,0 +1,57 @@
+package falcon
+
+// Hasher is an extendable-output hash function with the same absorb/
+// squeeze life cycle as PRNGContext: Init resets the state, Inject
+// absorbs data, Flip switches to output mode and Extract squeezes bytes.
+//
+// Only the message pre-hash used by SignPrehashed and VerifyPrehashed is
+// pluggable. The hash-to-point step inside Sign and Verify (over the
+// nonce and the message) always runs on the C PRNG context, because its
+// state is consumed directly by the C library; Fingerprint and the other
+// internal digests also stay on the C implementation so their values do
+// not depend on a process-wide setting.
+type Hasher interface {
+	Init()
+	Inject(data []byte)
+	Flip()
+	Extract(out []byte)
+}
+
+// PrehashSize is the length in bytes of the message digest signed by
+// SignPrehashed
+const PrehashSize = 64
+
+// NewHasher creates the Hasher used when nil is passed to SignPrehashed
+// or VerifyPrehashed. It defaults to the C PRNG context (SHAKE256 unless
+// the library was built with the Keccak256 PRNG) and may be replaced,
+// e.g. with a pure-Go SHAKE256, before any signing takes place.
+var NewHasher = func() Hasher {
+	return &PRNGContext{}
+}
+
+// prehash digests message with h into PrehashSize bytes
+func prehash(h Hasher, message []byte) []byte {
+	if h == nil {
+		h = NewHasher()
+	}
+	h.Init()
+	if len(message) > 0 {
+		h.Inject(message)
+	}
+	h.Flip()
+	digest := make([]byte, PrehashSize)
+	h.Extract(digest)
+	return digest
+}
+
+// SignPrehashed digests message with h (or NewHasher() if h is nil) and
+// signs the digest. The result must be checked with VerifyPrehashed
+// using a hasher that produces the same output.
+func SignPrehashed(message, privateKey []byte, sigType int, h Hasher) ([]byte, error) {
+	return Sign(prehash(h, message), privateKey, SignatureType(sigType))
+}
+
+// VerifyPrehashed verifies a signature produced by SignPrehashed
+func VerifyPrehashed(signature, message, publicKey []byte, sigType int, h Hasher) error {
+	return Verify(signature, prehash(h, message), publicKey, SignatureType(sigType))
+}
@@ -0,0 +1,51 @@
+//go:build cgo && go1.24
+
+package falcon
+
+import (
+	"crypto/sha3"
+	"testing"
+)
+
+// goShake256 adapts the standard library SHAKE256 to the Hasher interface
+type goShake256 struct {
+	h *sha3.SHAKE
+}
+
+func (g *goShake256) Init() {
+	g.h = sha3.NewSHAKE256()
+}
+
+func (g *goShake256) Inject(data []byte) {
+	g.h.Write(data)
+}
+
+func (g *goShake256) Flip() {}
+
+func (g *goShake256) Extract(out []byte) {
+	g.h.Read(out)
+}
+
+func TestSignPrehashedGoShake256(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	message := []byte("digested with a pure-Go SHAKE256")
+	signature, err := SignPrehashed(message, keyPair.PrivateKey, SigPadded, &goShake256{})
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	if err := VerifyPrehashed(signature, message, keyPair.PublicKey, SigPadded, &goShake256{}); err != nil {
+		t.Fatalf("Signature verification failed: %v", err)
+	}
+
+	// With the SHAKE256 PRNG, the C and Go digests are interchangeable
+	if getPRNGName() == "SHAKE256" {
+		if err := VerifyPrehashed(signature, message, keyPair.PublicKey, SigPadded, nil); err != nil {
+			t.Fatalf("C hasher disagrees with Go SHAKE256: %v", err)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+//go:build cgo
+
+package falcon
+
+import "testing"
+
+func TestSignPrehashed(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	message := []byte("message digested before signing")
+	signature, err := SignPrehashed(message, keyPair.PrivateKey, SigCompressed, nil)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	if err := VerifyPrehashed(signature, message, keyPair.PublicKey, SigCompressed, &PRNGContext{}); err != nil {
+		t.Fatalf("Signature verification failed: %v", err)
+	}
+
+	// The signature covers the digest, not the raw message
+	if err := Verify(signature, message, keyPair.PublicKey, SigCompressed); err == nil {
+		t.Fatal("Prehashed signature verified against the raw message")
+	}
+}
@@ -0,0 +1,194 @@
+//go:build cgo
+
+package falcon
+
+/*
+#include "falcon.h"
+#include "inner.h"
+
+// falcon_go_decode_sig_body decodes the part of a signature after the
+// header and nonce into out (n coefficients) and returns the number of
+// bytes consumed, or 0 if the bytes do not hold a complete, valid
+// encoding
+static size_t falcon_go_decode_sig_body(unsigned logn, int ct, const void *body, size_t len, int16_t *out) {
+    if (ct) {
+        return Zf(trim_i16_decode)(out, logn, Zf(max_sig_bits)[logn], body, len);
+    }
+    return Zf(comp_decode)(out, logn, body, len);
+}
+
+// falcon_go_encode_sig_body encodes the n coefficients of x as a
+// signature body into out and returns the number of bytes written, or 0
+// if the value does not fit in max_out_len bytes or cannot be
+// represented in the chosen encoding
+static size_t falcon_go_encode_sig_body(unsigned logn, int ct, void *out, size_t max_out_len, const int16_t *x) {
+    if (ct) {
+        return Zf(trim_i16_encode)(out, max_out_len, x, logn, Zf(max_sig_bits)[logn]);
+    }
+    return Zf(comp_encode)(out, max_out_len, x, logn);
+}
+
+// falcon_go_ntt_pubkey decodes an encoded public key into h and converts
+// it to NTT + Montgomery form, as falcon_verify_finish() does on every
+// call. Returns 0 or FALCON_ERR_FORMAT.
+static int falcon_go_ntt_pubkey(uint16_t *h, unsigned logn, const void *pubkey, size_t pubkey_len) {
+    if (Zf(modq_decode)(h, logn, (const uint8_t *)pubkey + 1, pubkey_len - 1) != pubkey_len - 1) {
+        return FALCON_ERR_FORMAT;
+    }
+    Zf(to_ntt_monty)(h, logn);
+    return 0;
+}
+
+// falcon_go_verify_ntt is the tail of falcon_verify_finish() for a public
+// key already in NTT form. The signature header and length must have
+// been checked, and hash_data must hold the nonce and message. tmp must
+// be 16-bit aligned with room for FALCON_TMPSIZE_VERIFY(logn) bytes.
+static int falcon_go_verify_ntt(const void *sig, size_t sig_len, int ct, int padded,
+    const uint16_t *h, unsigned logn, prng_context *hash_data, void *tmp)
+{
+    const uint8_t *es = sig;
+    size_t n = (size_t)1 << logn;
+    uint16_t *hm = tmp;
+    int16_t *sv = (int16_t *)(hm + n);
+    uint8_t *atmp = (uint8_t *)(sv + n);
+    size_t u = 41, v;
+
+    if (ct) {
+        v = Zf(trim_i16_decode)(sv, logn, Zf(max_sig_bits)[logn], es + u, sig_len - u);
+    } else {
+        v = Zf(comp_decode)(sv, logn, es + u, sig_len - u);
+    }
+    if (v == 0) {
+        return FALCON_ERR_FORMAT;
+    }
+    if (u + v != sig_len) {
+        if (!padded) {
+            return FALCON_ERR_FORMAT;
+        }
+        for (; u + v < sig_len; v++) {
+            if (es[u + v] != 0) {
+                return FALCON_ERR_FORMAT;
+            }
+        }
+    }
+
+    prng_flip(hash_data);
+    if (ct) {
+        Zf(hash_to_point_ct)((inner_prng_context *)hash_data, hm, logn, atmp);
+    } else {
+        Zf(hash_to_point_vartime)((inner_prng_context *)hash_data, hm, logn);
+    }
+    if (!Zf(verify_raw)(hm, sv, h, logn, atmp)) {
+        return FALCON_ERR_BADSIG;
+    }
+    return 0;
+}
+
+// falcon_go_shake256 writes out_len bytes of SHAKE256(in) to out. Unlike
+// the prng_* functions it is SHAKE256 whatever FALCON_PRNG_KECCAK256 is.
+static void falcon_go_shake256(void *out, size_t out_len, const void *in, size_t in_len) {
+    inner_shake256_context sc;
+
+    Zf(i_shake256_init)(&sc);
+    Zf(i_shake256_inject)(&sc, in, in_len);
+    Zf(i_shake256_flip)(&sc);
+    Zf(i_shake256_extract)(&sc, out, out_len);
+}
+*/
+import "C"
+import "runtime"
+
+// checkCodec reports whether the signature codec below is available; it
+// always is with cgo
+func checkCodec() error {
+	return nil
+}
+
+// fixedShake256 returns outLen bytes of SHAKE256(data). It does not go
+// through PRNGContext, so the result is the same in builds whose PRNG is
+// Keccak-256.
+func fixedShake256(outLen int, data []byte) []byte {
+	out := make([]byte, outLen)
+	C.falcon_go_shake256(ptr(out), C.size_t(outLen), ptr(data), C.size_t(len(data)))
+	runtime.KeepAlive(data)
+	return out
+}
+
+// decodeSigValue decodes a signature body (the bytes after the header
+// and nonce) without verifying it. It returns the signature value and
+// how many bytes its encoding occupies, or a zero length if body does
+// not start with a complete valid encoding. ct selects the
+// constant-time encoding instead of the compressed one.
+func decodeSigValue(body []byte, logN uint, ct bool) ([]int16, int) {
+	var cct C.int
+	if ct {
+		cct = 1
+	}
+	value := make([]int16, 1<<logN)
+	n := C.falcon_go_decode_sig_body(C.uint(logN), cct, ptr(body), C.size_t(len(body)), (*C.int16_t)(&value[0]))
+	runtime.KeepAlive(body)
+	return value, int(n)
+}
+
+// encodeSigValue encodes a signature value into out, in the
+// constant-time encoding if ct is set and the compressed one otherwise,
+// and returns the number of bytes written, or 0 if it does not fit or
+// cannot be represented
+func encodeSigValue(out []byte, value []int16, logN uint, ct bool) int {
+	var cct C.int
+	if ct {
+		cct = 1
+	}
+	n := C.falcon_go_encode_sig_body(C.uint(logN), cct, ptr(out), C.size_t(len(out)), (*C.int16_t)(&value[0]))
+	runtime.KeepAlive(out)
+	runtime.KeepAlive(value)
+	return int(n)
+}
+
+// decodeSigBody returns how many bytes the encoded signature value at the
+// start of body occupies, or 0 if it is not a complete valid encoding
+func decodeSigBody(body []byte, logN uint, ct bool) int {
+	_, n := decodeSigValue(body, logN, ct)
+	return n
+}
+
+// nttPublicKey decodes a public key whose header and length have been
+// checked and returns it in the NTT + Montgomery form used by the
+// verification core
+func nttPublicKey(publicKey []byte, logN uint) ([]uint16, error) {
+	h := make([]uint16, 1<<logN)
+	result := C.falcon_go_ntt_pubkey((*C.uint16_t)(&h[0]), C.uint(logN), ptr(publicKey), C.size_t(len(publicKey)))
+	runtime.KeepAlive(publicKey)
+	if result != 0 {
+		return nil, falconError(result)
+	}
+	return h, nil
+}
+
+// verifyNTT finishes a verification against a public key in NTT form.
+// signature must have passed checkSignature, hashData must hold the
+// nonce and message in input mode, and tmp must hold at least
+// tmpSizeVerify(logN) bytes.
+func verifyNTT(signature []byte, ct, padded bool, h []uint16, logN uint, hashData *PRNGContext, tmp []byte) error {
+	var cct, cpadded C.int
+	if ct {
+		cct = 1
+	}
+	if padded {
+		cpadded = 1
+	}
+
+	defer acquire()()
+
+	result := C.falcon_go_verify_ntt(
+		ptr(signature), C.size_t(len(signature)), cct, cpadded,
+		(*C.uint16_t)(&h[0]), C.uint(logN), &hashData.ctx, ptr(tmp),
+	)
+	runtime.KeepAlive(signature)
+	runtime.KeepAlive(h)
+	runtime.KeepAlive(tmp)
+	if result != 0 {
+		return falconError(result)
+	}
+	return nil
+}
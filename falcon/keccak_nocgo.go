@@ -0,0 +1,127 @@
+//go:build !cgo
+
+package falcon
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// Without cgo the PRNG is backed by this Go port of the Keccak-f[1600]
+// permutation, so that hashing helpers such as Fingerprint and Address
+// keep working. It produces the same SHAKE256 stream as the C library.
+
+var keccakRoundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808A, 0x8000000080008000,
+	0x000000000000808B, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008A, 0x0000000000000088, 0x0000000080008009, 0x000000008000000A,
+	0x000000008000808B, 0x800000000000008B, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800A, 0x800000008000000A,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+// keccakRotations and keccakPiLanes drive the combined rho and pi steps,
+// visiting the lanes along the pi cycle starting from lane 1
+var keccakRotations = [24]int{
+	1, 3, 6, 10, 15, 21, 28, 36, 45, 55, 2, 14,
+	27, 41, 56, 8, 25, 43, 62, 18, 39, 61, 20, 44,
+}
+
+var keccakPiLanes = [24]int{
+	10, 7, 11, 17, 18, 3, 5, 16, 8, 21, 24, 4,
+	15, 23, 19, 13, 12, 2, 20, 14, 22, 9, 6, 1,
+}
+
+func keccakF1600(a *[25]uint64) {
+	var c [5]uint64
+	for round := 0; round < 24; round++ {
+		// theta
+		for x := 0; x < 5; x++ {
+			c[x] = a[x] ^ a[x+5] ^ a[x+10] ^ a[x+15] ^ a[x+20]
+		}
+		for x := 0; x < 5; x++ {
+			d := c[(x+4)%5] ^ bits.RotateLeft64(c[(x+1)%5], 1)
+			for y := 0; y < 25; y += 5 {
+				a[y+x] ^= d
+			}
+		}
+
+		// rho and pi
+		t := a[1]
+		for i, j := range keccakPiLanes {
+			a[j], t = bits.RotateLeft64(t, keccakRotations[i]), a[j]
+		}
+
+		// chi
+		for y := 0; y < 25; y += 5 {
+			copy(c[:], a[y:y+5])
+			for x := 0; x < 5; x++ {
+				a[y+x] = c[x] ^ (^c[(x+1)%5] & c[(x+2)%5])
+			}
+		}
+
+		// iota
+		a[0] ^= keccakRoundConstants[round]
+	}
+}
+
+// shakeState is a SHAKE256 sponge. pos is the offset into the current
+// rate-sized block, for absorbing and squeezing alike.
+type shakeState struct {
+	a   [25]uint64
+	pos int
+}
+
+func (s *shakeState) reset() {
+	*s = shakeState{}
+}
+
+// xorByte adds b at byte offset i of the state; bytes are little-endian
+// within each 64-bit lane
+func (s *shakeState) xorByte(i int, b byte) {
+	s.a[i>>3] ^= uint64(b) << (8 * uint(i&7))
+}
+
+func (s *shakeState) absorb(data []byte) {
+	for _, b := range data {
+		s.xorByte(s.pos, b)
+		s.pos++
+		if s.pos == prngBufferSize {
+			keccakF1600(&s.a)
+			s.pos = 0
+		}
+	}
+}
+
+// pad applies the SHAKE domain separation and padding and readies the
+// state for squeezing
+func (s *shakeState) pad() {
+	s.xorByte(s.pos, 0x1F)
+	s.xorByte(prngBufferSize-1, 0x80)
+	s.pos = prngBufferSize
+}
+
+// fixedShake256 returns outLen bytes of SHAKE256(data), matching the cgo
+// build whatever PRNG the C library was built with
+func fixedShake256(outLen int, data []byte) []byte {
+	var s shakeState
+	s.absorb(data)
+	s.pad()
+	out := make([]byte, outLen)
+	s.squeeze(out)
+	return out
+}
+
+func (s *shakeState) squeeze(out []byte) {
+	var lane [8]byte
+	for len(out) > 0 {
+		if s.pos == prngBufferSize {
+			keccakF1600(&s.a)
+			s.pos = 0
+		}
+		binary.LittleEndian.PutUint64(lane[:], s.a[s.pos>>3])
+		n := copy(out, lane[s.pos&7:])
+		out = out[n:]
+		s.pos += n
+	}
+}
@@ -0,0 +1,291 @@
+package falcon
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"unsafe"
+)
+
+// PublicKey is an encoded Falcon public key. Its underlying type is
+// []byte, so plain byte slices can still be passed wherever a PublicKey
+// is expected and vice versa.
+type PublicKey []byte
+
+// PrivateKey is an encoded Falcon private key. Like PublicKey it
+// converts freely to and from []byte.
+type PrivateKey []byte
+
+// KeyPair represents a Falcon key pair.
+//
+// json.Marshal redacts the private key, so json.Unmarshal cannot decode
+// its output; encode with MarshalKeyPairWithPrivate to round-trip a
+// KeyPair through JSON.
+type KeyPair struct {
+	PublicKey  PublicKey
+	PrivateKey PrivateKey
+}
+
+// Bytes returns the encoded key, sharing its backing array
+func (k PublicKey) Bytes() []byte {
+	return []byte(k)
+}
+
+// LogN returns the degree (log2) of the key, read from its header
+// without calling into C
+func (k PublicKey) LogN() (uint, error) {
+	logN, err := checkPublicKey(k)
+	if err != nil {
+		return 0, fmt.Errorf("invalid public key: %w", err)
+	}
+	return logN, nil
+}
+
+// Equal reports whether k and x are the same public key. x must be a
+// PublicKey; any other type compares unequal.
+func (k PublicKey) Equal(x crypto.PublicKey) bool {
+	other, ok := x.(PublicKey)
+	return ok && bytes.Equal(k, other)
+}
+
+// Bytes returns the encoded key, sharing its backing array
+func (k PrivateKey) Bytes() []byte {
+	return []byte(k)
+}
+
+// LogN returns the degree (log2) of the key, read from its header
+// without calling into C
+func (k PrivateKey) LogN() (uint, error) {
+	logN, err := checkPrivateKey(k)
+	if err != nil {
+		return 0, fmt.Errorf("invalid private key: %w", err)
+	}
+	return logN, nil
+}
+
+// Equal reports whether k and x are the same private key, in constant
+// time. x must be a PrivateKey; any other type compares unequal.
+func (k PrivateKey) Equal(x crypto.PrivateKey) bool {
+	other, ok := x.(PrivateKey)
+	return ok && PrivateKeyEqual(k, other)
+}
+
+// AsPublicKey returns x as a PublicKey if it is one, or a []byte to be
+// interpreted as one, as handed out by crypto.Signer implementations
+func AsPublicKey(x crypto.PublicKey) (PublicKey, bool) {
+	switch k := x.(type) {
+	case PublicKey:
+		return k, true
+	case []byte:
+		return PublicKey(k), true
+	}
+	return nil, false
+}
+
+// AsPrivateKey returns x as a PrivateKey if it is one, or a []byte to be
+// interpreted as one
+func AsPrivateKey(x crypto.PrivateKey) (PrivateKey, bool) {
+	switch k := x.(type) {
+	case PrivateKey:
+		return k, true
+	case []byte:
+		return PrivateKey(k), true
+	}
+	return nil, false
+}
+
+// DerivePublicKey computes the public key matching an encoded private
+// key, for storage schemes that keep only the private key. Malformed
+// private keys yield errors matching ErrBadFormat.
+func DerivePublicKey(privateKey []byte) ([]byte, error) {
+	if _, err := checkPrivateKey(privateKey); err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return derivePublicKey(privateKey)
+}
+
+// LogN returns the degree (log2) of the key pair, read from the public
+// key header, or the private key header if there is no public key,
+// without calling into C.
+func (kp *KeyPair) LogN() (int, error) {
+	if len(kp.PublicKey) > 0 {
+		logN, err := checkPublicKey(kp.PublicKey)
+		if err != nil {
+			return 0, fmt.Errorf("invalid public key: %w", err)
+		}
+		return int(logN), nil
+	}
+	logN, err := checkPrivateKey(kp.PrivateKey)
+	if err != nil {
+		return 0, fmt.Errorf("invalid private key: %w", err)
+	}
+	return int(logN), nil
+}
+
+// SecureZero overwrites b with zeros. The writes go through an unsafe
+// pointer and b is kept alive past them, so the compiler cannot drop
+// them as dead stores even when b is never read again. Go may already
+// have copied the contents elsewhere (for instance when a slice grew),
+// so this limits rather than eliminates exposure.
+func SecureZero(b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	p := unsafe.Pointer(unsafe.SliceData(b))
+	for i := range b {
+		*(*byte)(unsafe.Add(p, i)) = 0
+	}
+	runtime.KeepAlive(b)
+}
+
+// Zero overwrites the private key with zeros using SecureZero. Call it in
+// a defer as soon as the key pair is obtained so that the key is wiped on
+// every return path:
+//
+//	kp, err := falcon.GenerateKeyPair(9)
+//	if err != nil {
+//		return err
+//	}
+//	defer kp.Zero()
+//
+// The public key is left intact. Copies of the private key made
+// elsewhere, including by the caller, are not affected.
+func (kp *KeyPair) Zero() {
+	SecureZero(kp.PrivateKey)
+}
+
+// keyPairJSONVersion is the version of the KeyPair JSON layout
+const keyPairJSONVersion = "1"
+
+// keyPairJSON is the JSON form of a KeyPair. PrivateKeyLen replaces the
+// private key in the redacted form.
+type keyPairJSON struct {
+	Version       string `json:"version"`
+	LogN          int    `json:"logN,omitempty"`
+	PublicKey     []byte `json:"publicKey"`
+	PrivateKey    []byte `json:"privateKey,omitempty"`
+	PrivateKeyLen int    `json:"privateKeyLen,omitempty"`
+}
+
+// String describes the key pair without revealing the private key
+func (kp KeyPair) String() string {
+	return fmt.Sprintf("KeyPair{PublicKey: %d bytes, PrivateKey: redacted (%d bytes)}",
+		len(kp.PublicKey), len(kp.PrivateKey))
+}
+
+// GoString is used by %#v and redacts the private key like String
+func (kp KeyPair) GoString() string {
+	return kp.String()
+}
+
+// Format makes every formatting verb, including %x and %d, print the
+// redacted String form
+func (kp KeyPair) Format(f fmt.State, verb rune) {
+	io.WriteString(f, kp.String())
+}
+
+// MarshalJSON encodes the version, degree and public key and only the
+// length of the private key, so a KeyPair can be logged safely. The
+// result does not round-trip: UnmarshalJSON rejects it for lack of a
+// private key. Use MarshalKeyPairWithPrivate to include the private key.
+func (kp KeyPair) MarshalJSON() ([]byte, error) {
+	logN, _ := kp.LogN()
+	return json.Marshal(keyPairJSON{
+		Version:       keyPairJSONVersion,
+		LogN:          logN,
+		PublicKey:     kp.PublicKey,
+		PrivateKeyLen: len(kp.PrivateKey),
+	})
+}
+
+// MarshalKeyPairWithPrivate encodes kp as JSON including the private
+// key; UnmarshalJSON decodes the result
+func MarshalKeyPairWithPrivate(kp *KeyPair) ([]byte, error) {
+	logN, _ := kp.LogN()
+	return json.Marshal(keyPairJSON{
+		Version:    keyPairJSONVersion,
+		LogN:       logN,
+		PublicKey:  kp.PublicKey,
+		PrivateKey: kp.PrivateKey,
+	})
+}
+
+// UnmarshalJSON decodes the output of MarshalKeyPairWithPrivate. The
+// version, logN, publicKey and privateKey fields are required, the
+// version must be "1", and both keys must be well formed and of degree
+// logN; unknown fields are ignored. The redacted MarshalJSON form
+// carries no private key and is rejected.
+func (kp *KeyPair) UnmarshalJSON(data []byte) error {
+	var v keyPairJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch {
+	case v.Version == "":
+		return errors.New("key pair JSON: missing version")
+	case v.Version != keyPairJSONVersion:
+		return fmt.Errorf("unsupported key pair JSON version %q", v.Version)
+	case v.LogN == 0:
+		return errors.New("key pair JSON: missing logN")
+	case len(v.PublicKey) == 0:
+		return errors.New("key pair JSON: missing publicKey")
+	case len(v.PrivateKey) == 0:
+		return errors.New("key pair JSON: missing privateKey")
+	}
+
+	pubLogN, err := checkPublicKey(v.PublicKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	privLogN, err := checkPrivateKey(v.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("invalid private key: %w", err)
+	}
+	if int(pubLogN) != v.LogN || int(privLogN) != v.LogN {
+		return fmt.Errorf("%w: logN %d does not match keys of degree %d and %d",
+			ErrBadFormat, v.LogN, pubLogN, privLogN)
+	}
+
+	*kp = KeyPair{PublicKey: v.PublicKey, PrivateKey: v.PrivateKey}
+	return nil
+}
+
+// FalconSignerOpts selects the signature encoding used by
+// PrivateKey.Sign. Hash reports how the signed bytes were pre-hashed by
+// the caller, if at all; Falcon signs them as given either way.
+type FalconSignerOpts struct {
+	Hash    crypto.Hash
+	SigType int
+}
+
+// HashFunc implements crypto.SignerOpts
+func (o *FalconSignerOpts) HashFunc() crypto.Hash {
+	return o.Hash
+}
+
+// Public returns the public key matching k, or nil if k is not a valid
+// private key. It implements crypto.Signer.
+func (k PrivateKey) Public() crypto.PublicKey {
+	pub, err := derivePublicKey(k)
+	if err != nil {
+		return nil
+	}
+	return PublicKey(pub)
+}
+
+// Sign signs digest with k and implements crypto.Signer. Falcon hashes
+// its input internally, so digest may be a message or a pre-computed
+// digest and is signed as given. The encoding is SigCompressed unless
+// opts is a *FalconSignerOpts selecting another one. Like ed25519, the
+// rand argument is ignored: randomness comes from the system RNG.
+func (k PrivateKey) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	sigType := SigCompressed
+	if o, ok := opts.(*FalconSignerOpts); ok && o != nil && o.SigType != 0 {
+		sigType = o.SigType
+	}
+	return Sign(digest, k, SignatureType(sigType))
+}
@@ -0,0 +1,378 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestKeyPairRedaction(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	// A slice of the private key long enough not to appear by chance
+	secret := keyPair.PrivateKey[1:17]
+	leaks := func(s string) bool {
+		return strings.Contains(s, hex.EncodeToString(secret)) ||
+			strings.Contains(s, base64.StdEncoding.EncodeToString(secret)[:20]) ||
+			strings.Contains(s, strings.Trim(fmt.Sprint([]byte(secret)), "[]"))
+	}
+
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%x", "%d"} {
+		for _, v := range []interface{}{keyPair, *keyPair} {
+			if out := fmt.Sprintf(verb, v); leaks(out) {
+				t.Fatalf("%s formatting of %T leaks the private key: %s", verb, v, out)
+			}
+		}
+	}
+
+	data, err := json.Marshal(keyPair)
+	if err != nil {
+		t.Fatalf("Failed to marshal key pair: %v", err)
+	}
+	if leaks(string(data)) || bytes.Contains(data, []byte(`"privateKey"`)) {
+		t.Fatalf("JSON leaks the private key: %s", data)
+	}
+	if !bytes.Contains(data, []byte(`"privateKeyLen":1281`)) {
+		t.Fatalf("JSON does not report the private key length: %s", data)
+	}
+
+	full, err := MarshalKeyPairWithPrivate(keyPair)
+	if err != nil {
+		t.Fatalf("Failed to marshal key pair: %v", err)
+	}
+	var decoded struct {
+		PublicKey  []byte `json:"publicKey"`
+		PrivateKey []byte `json:"privateKey"`
+	}
+	if err := json.Unmarshal(full, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal key pair: %v", err)
+	}
+	if !bytes.Equal(decoded.PrivateKey, keyPair.PrivateKey) || !bytes.Equal(decoded.PublicKey, keyPair.PublicKey) {
+		t.Fatal("Explicit marshaling did not include the keys")
+	}
+}
+
+func TestTypedKeyMethods(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	pub, priv := keyPair.PublicKey, keyPair.PrivateKey
+
+	for name, get := range map[string]func() (uint, error){
+		"public":  pub.LogN,
+		"private": priv.LogN,
+	} {
+		logN, err := get()
+		if err != nil {
+			t.Fatalf("%s: LogN failed: %v", name, err)
+		}
+		if logN != 9 {
+			t.Fatalf("%s: LogN() = %d, want 9", name, logN)
+		}
+	}
+	if _, err := PublicKey(priv).LogN(); !errors.Is(err, ErrBadHeader) {
+		t.Fatalf("Expected ErrBadHeader for a private key read as public, got %v", err)
+	}
+	if _, err := PrivateKey(pub).LogN(); !errors.Is(err, ErrBadHeader) {
+		t.Fatalf("Expected ErrBadHeader for a public key read as private, got %v", err)
+	}
+
+	if !bytes.Equal(pub.Bytes(), keyPair.PublicKey) || !bytes.Equal(priv.Bytes(), keyPair.PrivateKey) {
+		t.Fatal("Bytes does not return the encoded key")
+	}
+
+	other, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	if !pub.Equal(PublicKey(append([]byte{}, pub...))) || pub.Equal(other.PublicKey) {
+		t.Fatal("PublicKey.Equal gave the wrong answer")
+	}
+	if !priv.Equal(PrivateKey(append([]byte{}, priv...))) || priv.Equal(other.PrivateKey) {
+		t.Fatal("PrivateKey.Equal gave the wrong answer")
+	}
+	if pub.Equal([]byte(pub)) || priv.Equal(pub) {
+		t.Fatal("Equal matched a value of another type")
+	}
+
+	if k, ok := AsPublicKey([]byte(pub)); !ok || !pub.Equal(k) {
+		t.Fatal("AsPublicKey rejected a byte slice")
+	}
+	if k, ok := AsPrivateKey(priv); !ok || !priv.Equal(k) {
+		t.Fatal("AsPrivateKey rejected a PrivateKey")
+	}
+	if _, ok := AsPublicKey("not a key"); ok {
+		t.Fatal("AsPublicKey accepted a string")
+	}
+
+	// Raw byte slices are still accepted by the typed API
+	raw := []byte(priv)
+	signature, err := Sign([]byte("typed"), raw, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	if err := Verify(signature, []byte("typed"), []byte(pub), SigCompressed); err != nil {
+		t.Fatalf("Signature verification failed: %v", err)
+	}
+}
+
+func TestPrivateKeyCryptoSigner(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	priv := keyPair.PrivateKey
+
+	var signer crypto.Signer = priv
+	pub, ok := signer.Public().(PublicKey)
+	if !ok || !bytes.Equal(pub, keyPair.PublicKey) {
+		t.Fatal("Public does not return the matching public key")
+	}
+
+	digest := []byte("tls transcript hash")
+	signature, err := signer.Sign(rand.Reader, digest, crypto.Hash(0))
+	if err != nil {
+		t.Fatalf("Failed to sign: %v", err)
+	}
+	if err := Verify(signature, digest, keyPair.PublicKey, SigCompressed); err != nil {
+		t.Fatalf("Default signature is not a valid compressed signature: %v", err)
+	}
+
+	signature, err = signer.Sign(rand.Reader, digest, &FalconSignerOpts{SigType: SigCT})
+	if err != nil {
+		t.Fatalf("Failed to sign: %v", err)
+	}
+	if err := VerifyStrictFormat(signature, digest, keyPair.PublicKey, SigCT); err != nil {
+		t.Fatalf("Options did not select the CT format: %v", err)
+	}
+
+	if _, err := signer.Sign(rand.Reader, digest, &FalconSignerOpts{SigType: 9}); err == nil {
+		t.Fatal("Expected error for invalid signature type")
+	}
+	if PrivateKey(keyPair.PublicKey).Public() != nil {
+		t.Fatal("Expected nil public key for an invalid private key")
+	}
+}
+
+func TestDerivePublicKey(t *testing.T) {
+	for _, logN := range []uint{9, 10} {
+		keyPair, err := GenerateKeyPair(logN)
+		if err != nil {
+			t.Fatalf("Failed to generate key pair: %v", err)
+		}
+		pub, err := DerivePublicKey(keyPair.PrivateKey)
+		if err != nil {
+			t.Fatalf("Failed to derive public key: %v", err)
+		}
+		if !bytes.Equal(pub, keyPair.PublicKey) {
+			t.Fatalf("logN %d: derived public key differs from the generated one", logN)
+		}
+	}
+
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	for name, bad := range map[string][]byte{
+		"empty":      nil,
+		"public key": keyPair.PublicKey,
+		"truncated":  keyPair.PrivateKey[:100],
+	} {
+		if _, err := DerivePublicKey(bad); !errors.Is(err, ErrBadFormat) {
+			t.Fatalf("%s: expected ErrBadFormat, got %v", name, err)
+		}
+	}
+}
+
+func TestKeyPairLogN(t *testing.T) {
+	for _, logN := range []uint{2, 9, 10} {
+		keyPair, err := GenerateKeyPair(logN)
+		if err != nil {
+			t.Fatalf("Failed to generate key pair: %v", err)
+		}
+		want, err := GetLogN(keyPair.PublicKey)
+		if err != nil {
+			t.Fatalf("Failed to get logN: %v", err)
+		}
+
+		for name, kp := range map[string]*KeyPair{
+			"generated":    keyPair,
+			"loaded":       {PublicKey: keyPair.PublicKey, PrivateKey: keyPair.PrivateKey},
+			"unkeyed":      {keyPair.PublicKey, keyPair.PrivateKey},
+			"private only": {PrivateKey: keyPair.PrivateKey},
+		} {
+			got, err := kp.LogN()
+			if err != nil {
+				t.Fatalf("%s: LogN failed: %v", name, err)
+			}
+			if got != want {
+				t.Fatalf("%s: LogN() = %d, GetLogN = %d", name, got, want)
+			}
+		}
+	}
+
+	// Replacing the keys of a generated pair changes its degree
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	other, err := GenerateKeyPair(10)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	keyPair.PublicKey, keyPair.PrivateKey = other.PublicKey, other.PrivateKey
+	if got, err := keyPair.LogN(); err != nil || got != 10 {
+		t.Fatalf("LogN() = %d, %v after replacing the keys; want 10", got, err)
+	}
+
+	if _, err := (&KeyPair{PublicKey: []byte{0x0F}}).LogN(); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("Expected ErrBadFormat, got %v", err)
+	}
+}
+
+func TestKeyPairZero(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	publicKey := append([]byte{}, keyPair.PublicKey...)
+	privateLen := len(keyPair.PrivateKey)
+
+	keyPair.Zero()
+	if len(keyPair.PrivateKey) != privateLen {
+		t.Fatalf("Zero changed the private key length to %d", len(keyPair.PrivateKey))
+	}
+	if !bytes.Equal(keyPair.PrivateKey, make([]byte, privateLen)) {
+		t.Fatal("Private key not zeroed")
+	}
+	if !bytes.Equal(keyPair.PublicKey, publicKey) {
+		t.Fatal("Zero modified the public key")
+	}
+}
+
+func TestSecureZero(t *testing.T) {
+	b := []byte("sensitive")
+	SecureZero(b)
+	if !bytes.Equal(b, make([]byte, len(b))) {
+		t.Fatalf("SecureZero left %q", b)
+	}
+	SecureZero(nil)
+
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	keyPair.Zero()
+	if !bytes.Equal(keyPair.PrivateKey, make([]byte, len(keyPair.PrivateKey))) {
+		t.Fatal("Zero left private key material")
+	}
+}
+
+func ExampleKeyPair_Zero() {
+	kp, err := GenerateKeyPair(9)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	// Wipe the private key on every return path
+	defer kp.Zero()
+
+	message := []byte("hello")
+	sig, err := Sign(message, kp.PrivateKey, SigCompressed)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(Verify(sig, message, kp.PublicKey, SigCompressed) == nil)
+	// Output: true
+}
+
+func TestKeyPairJSON(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	data, err := MarshalKeyPairWithPrivate(keyPair)
+	if err != nil {
+		t.Fatalf("Failed to marshal key pair: %v", err)
+	}
+	if !bytes.Contains(data, []byte(`"logN":9`)) || !bytes.Contains(data, []byte(`"version":"1"`)) {
+		t.Fatalf("JSON lacks logN or version: %s", data)
+	}
+
+	var decoded KeyPair
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal key pair: %v", err)
+	}
+	if !decoded.PublicKey.Equal(keyPair.PublicKey) || !decoded.PrivateKey.Equal(keyPair.PrivateKey) {
+		t.Fatal("Key pair changed in JSON round trip")
+	}
+
+	redacted, err := json.Marshal(keyPair)
+	if err != nil {
+		t.Fatalf("Failed to marshal key pair: %v", err)
+	}
+	if !bytes.Contains(redacted, []byte(`"logN":9`)) {
+		t.Fatalf("Redacted JSON lacks logN: %s", redacted)
+	}
+	if err := json.Unmarshal(redacted, &KeyPair{}); err == nil {
+		t.Fatal("Redacted JSON decoded as a key pair")
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Failed to unmarshal key pair: %v", err)
+	}
+	encode := func(edit func(map[string]interface{})) []byte {
+		m := make(map[string]interface{}, len(fields))
+		for k, v := range fields {
+			m[k] = v
+		}
+		edit(m)
+		b, err := json.Marshal(m)
+		if err != nil {
+			t.Fatalf("Failed to marshal test input: %v", err)
+		}
+		return b
+	}
+
+	if err := json.Unmarshal(encode(func(m map[string]interface{}) { m["comment"] = "ignored" }), &decoded); err != nil {
+		t.Fatalf("Unknown field rejected: %v", err)
+	}
+
+	other, err := GenerateKeyPair(10)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	for name, edit := range map[string]func(map[string]interface{}){
+		"Redacted":         func(m map[string]interface{}) { delete(m, "privateKey"); m["privateKeyLen"] = 1281 },
+		"MissingPublicKey": func(m map[string]interface{}) { delete(m, "publicKey") },
+		"MissingLogN":      func(m map[string]interface{}) { delete(m, "logN") },
+		"MismatchedLogN":   func(m map[string]interface{}) { m["logN"] = 10 },
+		"MixedDegrees":     func(m map[string]interface{}) { m["publicKey"] = []byte(other.PublicKey) },
+		"MissingVersion":   func(m map[string]interface{}) { delete(m, "version") },
+		"UnknownVersion":   func(m map[string]interface{}) { m["version"] = "2" },
+		"PrivateAsPublic":  func(m map[string]interface{}) { m["publicKey"] = []byte(keyPair.PrivateKey) },
+	} {
+		kp := KeyPair{PublicKey: []byte("unchanged")}
+		if err := json.Unmarshal(encode(edit), &kp); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+		if string(kp.PublicKey) != "unchanged" {
+			t.Errorf("%s: failed unmarshal modified the key pair", name)
+		}
+	}
+}
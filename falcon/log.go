@@ -0,0 +1,15 @@
+package falcon
+
+import "log/slog"
+
+// Logger receives structured warnings about rare internal events such
+// as retries. It is nil by default, which disables logging. Set it
+// before using the package; it is not synchronized.
+var Logger *slog.Logger
+
+// logWarn emits a warning through Logger if one is installed
+func logWarn(msg string, args ...any) {
+	if l := Logger; l != nil {
+		l.Warn(msg, args...)
+	}
+}
@@ -0,0 +1,41 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLoggerWarnsOnRetry(t *testing.T) {
+	var buf bytes.Buffer
+	Logger = slog.New(slog.NewTextHandler(&buf, nil))
+	defer func() { Logger = nil }()
+
+	failures := 1
+	keyPairCheck = func(kp *KeyPair) error {
+		if failures > 0 {
+			failures--
+			return errors.New("forced failure")
+		}
+		return checkKeyPair(kp)
+	}
+	defer func() { keyPairCheck = checkKeyPair }()
+
+	if _, err := GenerateValidatedKeyPair(9); err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "level=WARN") || !strings.Contains(out, "forced failure") {
+		t.Fatalf("Expected a retry warning, got %q", out)
+	}
+}
+
+func TestLoggerNil(t *testing.T) {
+	// Logging with no Logger installed must be a no-op
+	logWarn("unused", "key", 1)
+}
@@ -0,0 +1,105 @@
+package falcon
+
+import (
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PEM block types are "FALCON-<degree> PRIVATE KEY" and
+// "FALCON-<degree> PUBLIC KEY", e.g. "FALCON-512 PUBLIC KEY"
+const (
+	pemPrivateKeySuffix = " PRIVATE KEY"
+	pemPublicKeySuffix  = " PUBLIC KEY"
+)
+
+// pemBlockType returns the block type for a key of degree 2^logN
+func pemBlockType(logN uint, suffix string) string {
+	return fmt.Sprintf("FALCON-%d%s", 1<<logN, suffix)
+}
+
+// MarshalPrivateKeyPEM encodes a private key as a PEM block whose type
+// names the degree, e.g. "FALCON-512 PRIVATE KEY"
+func MarshalPrivateKeyPEM(privateKey []byte) ([]byte, error) {
+	logN, err := checkPrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemBlockType(logN, pemPrivateKeySuffix), Bytes: privateKey}), nil
+}
+
+// MarshalPublicKeyPEM encodes a public key as a PEM block whose type
+// names the degree, e.g. "FALCON-512 PUBLIC KEY"
+func MarshalPublicKeyPEM(publicKey []byte) ([]byte, error) {
+	logN, err := checkPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemBlockType(logN, pemPublicKeySuffix), Bytes: publicKey}), nil
+}
+
+// UnmarshalPrivateKeyPEM decodes the first PEM block of data, which must
+// be a Falcon private key whose encoded degree matches its block type
+func UnmarshalPrivateKeyPEM(data []byte) ([]byte, error) {
+	return decodeKeyPEM(data, pemPrivateKeySuffix, checkPrivateKey)
+}
+
+// UnmarshalPublicKeyPEM decodes the first PEM block of data, which must
+// be a Falcon public key whose encoded degree matches its block type
+func UnmarshalPublicKeyPEM(data []byte) ([]byte, error) {
+	return decodeKeyPEM(data, pemPublicKeySuffix, checkPublicKey)
+}
+
+// decodeKeyPEM decodes a key block of the kind named by suffix and
+// checks its contents with check
+func decodeKeyPEM(data []byte, suffix string, check func([]byte) (uint, error)) ([]byte, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	degreeText, ok := strings.CutPrefix(block.Type, "FALCON-")
+	if ok {
+		degreeText, ok = strings.CutSuffix(degreeText, suffix)
+	}
+	if !ok {
+		return nil, fmt.Errorf("unexpected PEM block type %q, want FALCON-<degree>%s", block.Type, suffix)
+	}
+	degree, err := strconv.Atoi(degreeText)
+	if err != nil {
+		return nil, fmt.Errorf("bad degree in PEM block type %q", block.Type)
+	}
+
+	logN, err := check(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key in PEM block: %w", err)
+	}
+	if degree != 1<<logN {
+		return nil, fmt.Errorf("PEM block type %q does not match the Falcon-%d key it holds", block.Type, 1<<logN)
+	}
+	return block.Bytes, nil
+}
+
+// MarshalPublicKeyPEM encodes the pair's public key as a PEM block; see
+// the package-level MarshalPublicKeyPEM. It returns nil if the public key
+// is malformed.
+func (kp *KeyPair) MarshalPublicKeyPEM() []byte {
+	data, err := MarshalPublicKeyPEM(kp.PublicKey)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// MarshalPrivateKeyPEM encodes the pair's private key as a PEM block; see
+// the package-level MarshalPrivateKeyPEM. It returns nil if the private
+// key is malformed.
+func (kp *KeyPair) MarshalPrivateKeyPEM() []byte {
+	data, err := MarshalPrivateKeyPEM(kp.PrivateKey)
+	if err != nil {
+		return nil
+	}
+	return data
+}
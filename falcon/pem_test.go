@@ -0,0 +1,115 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestKeyPEM(t *testing.T) {
+	for _, logN := range []uint{9, 10} {
+		keyPair, err := GenerateKeyPair(logN)
+		if err != nil {
+			t.Fatalf("Failed to generate key pair: %v", err)
+		}
+
+		privPEM, err := MarshalPrivateKeyPEM(keyPair.PrivateKey)
+		if err != nil {
+			t.Fatalf("Failed to marshal private key: %v", err)
+		}
+		pubPEM, err := MarshalPublicKeyPEM(keyPair.PublicKey)
+		if err != nil {
+			t.Fatalf("Failed to marshal public key: %v", err)
+		}
+
+		wantHeader := "-----BEGIN FALCON-" + map[uint]string{9: "512", 10: "1024"}[logN]
+		if !bytes.HasPrefix(privPEM, []byte(wantHeader+" PRIVATE KEY-----")) {
+			t.Fatalf("Unexpected private key PEM header: %s", privPEM[:40])
+		}
+		if !bytes.HasPrefix(pubPEM, []byte(wantHeader+" PUBLIC KEY-----")) {
+			t.Fatalf("Unexpected public key PEM header: %s", pubPEM[:40])
+		}
+
+		priv, err := UnmarshalPrivateKeyPEM(privPEM)
+		if err != nil {
+			t.Fatalf("Failed to unmarshal private key: %v", err)
+		}
+		pub, err := UnmarshalPublicKeyPEM(pubPEM)
+		if err != nil {
+			t.Fatalf("Failed to unmarshal public key: %v", err)
+		}
+		if !bytes.Equal(priv, keyPair.PrivateKey) || !bytes.Equal(pub, keyPair.PublicKey) {
+			t.Fatal("PEM round trip changed the keys")
+		}
+
+		// Block kinds are not interchangeable
+		if _, err := UnmarshalPublicKeyPEM(privPEM); err == nil || errors.Is(err, ErrBadFormat) {
+			t.Fatalf("Expected a descriptive block type error, got %v", err)
+		}
+		if _, err := UnmarshalPrivateKeyPEM(pubPEM); err == nil || errors.Is(err, ErrBadFormat) {
+			t.Fatalf("Expected a descriptive block type error, got %v", err)
+		}
+	}
+
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	// A Falcon-512 key relabeled as Falcon-1024 is rejected
+	relabeled := pem.EncodeToMemory(&pem.Block{Type: "FALCON-1024 PUBLIC KEY", Bytes: keyPair.PublicKey})
+	if _, err := UnmarshalPublicKeyPEM(relabeled); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("Expected degree mismatch error, got %v", err)
+	}
+
+	if _, err := UnmarshalPublicKeyPEM([]byte("not pem")); err == nil || errors.Is(err, ErrBadFormat) {
+		t.Fatalf("Expected a descriptive error for non-PEM input, got %v", err)
+	}
+	other := pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: keyPair.PublicKey})
+	if _, err := UnmarshalPublicKeyPEM(other); err == nil || !strings.Contains(err.Error(), "RSA PUBLIC KEY") {
+		t.Fatalf("Expected error naming the block type, got %v", err)
+	}
+
+	if _, err := MarshalPrivateKeyPEM(keyPair.PublicKey); err == nil {
+		t.Fatal("Expected error marshaling a public key as private")
+	}
+}
+
+func TestKeyPairPEMMethods(t *testing.T) {
+	for _, logN := range []uint{9, 10} {
+		keyPair, err := GenerateKeyPair(logN)
+		if err != nil {
+			t.Fatalf("Failed to generate key pair: %v", err)
+		}
+
+		pubPEM := keyPair.MarshalPublicKeyPEM()
+		privPEM := keyPair.MarshalPrivateKeyPEM()
+		if !bytes.Contains(pubPEM, []byte(pemBlockType(logN, pemPublicKeySuffix))) {
+			t.Fatalf("Public key PEM does not name the degree:\n%s", pubPEM)
+		}
+
+		pub, err := UnmarshalPublicKeyPEM(pubPEM)
+		if err != nil {
+			t.Fatalf("Failed to parse public key PEM: %v", err)
+		}
+		priv, err := UnmarshalPrivateKeyPEM(privPEM)
+		if err != nil {
+			t.Fatalf("Failed to parse private key PEM: %v", err)
+		}
+		if !bytes.Equal(pub, keyPair.PublicKey) || !bytes.Equal(priv, keyPair.PrivateKey) {
+			t.Fatalf("logN %d: PEM round trip changed the keys", logN)
+		}
+
+		if _, err := UnmarshalPublicKeyPEM(privPEM); err == nil {
+			t.Fatal("Expected error parsing a private key block as a public key")
+		}
+	}
+
+	if (&KeyPair{}).MarshalPublicKeyPEM() != nil {
+		t.Fatal("Expected nil PEM for an empty key pair")
+	}
+}
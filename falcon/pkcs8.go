@@ -0,0 +1,98 @@
+package falcon
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+)
+
+// Algorithm OIDs for Falcon keys, by logN.
+//
+// TODO: these are the experimental arcs assigned by the Open Quantum Safe
+// project, which is what existing post-quantum tooling understands today.
+// Switch to the NIST-registered FN-DSA OIDs once they are published; keys
+// encoded with the old OIDs will then need re-encoding.
+var falconOIDs = map[uint]asn1.ObjectIdentifier{
+	9:  {1, 3, 9999, 3, 11},
+	10: {1, 3, 9999, 3, 14},
+}
+
+// pkcs8 is the PrivateKeyInfo structure of RFC 5208, laid out exactly as
+// crypto/x509 parses it
+type pkcs8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+// falconOID returns the algorithm OID for keys of degree 2^logN
+func falconOID(logN uint) (asn1.ObjectIdentifier, error) {
+	oid, ok := falconOIDs[logN]
+	if !ok {
+		return nil, fmt.Errorf("no algorithm OID for Falcon-%d", 1<<logN)
+	}
+	return oid, nil
+}
+
+// falconOIDLogN returns the degree identified by an algorithm OID
+func falconOIDLogN(oid asn1.ObjectIdentifier) (uint, error) {
+	for logN, known := range falconOIDs {
+		if oid.Equal(known) {
+			return logN, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown algorithm OID %s", oid)
+}
+
+// MarshalPKCS8PrivateKey encodes a Falcon-512 or Falcon-1024 private key
+// as a DER PKCS#8 PrivateKeyInfo. The algorithm identifier carries the
+// Falcon OID for the key's degree with absent parameters, and the private
+// key octet string holds the standard Falcon private key encoding.
+func MarshalPKCS8PrivateKey(privateKey []byte) ([]byte, error) {
+	logN, err := checkPrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	oid, err := falconOID(logN)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(pkcs8{
+		Algo:       pkix.AlgorithmIdentifier{Algorithm: oid},
+		PrivateKey: privateKey,
+	})
+}
+
+// ParsePKCS8PrivateKey decodes a DER PKCS#8 PrivateKeyInfo holding a
+// Falcon private key and returns the encoded key. The key's degree must
+// match the algorithm OID.
+func ParsePKCS8PrivateKey(der []byte) ([]byte, error) {
+	var info pkcs8
+	rest, err := asn1.Unmarshal(der, &info)
+	if err != nil {
+		return nil, fmt.Errorf("malformed PKCS#8 private key: %w", err)
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("trailing data after PKCS#8 private key")
+	}
+	if info.Version != 0 {
+		return nil, fmt.Errorf("unsupported PKCS#8 version %d", info.Version)
+	}
+	if len(info.Algo.Parameters.FullBytes) > 0 {
+		return nil, errors.New("unexpected parameters in Falcon algorithm identifier")
+	}
+	oidLogN, err := falconOIDLogN(info.Algo.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	logN, err := checkPrivateKey(info.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key in PKCS#8: %w", err)
+	}
+	if logN != oidLogN {
+		return nil, fmt.Errorf("PKCS#8 algorithm is Falcon-%d but the key is Falcon-%d", 1<<oidLogN, 1<<logN)
+	}
+	return info.PrivateKey, nil
+}
@@ -0,0 +1,96 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"testing"
+)
+
+func TestPKCS8RoundTrip(t *testing.T) {
+	for _, logN := range []uint{9, 10} {
+		keyPair, err := GenerateKeyPair(logN)
+		if err != nil {
+			t.Fatalf("Failed to generate key pair: %v", err)
+		}
+
+		der, err := MarshalPKCS8PrivateKey(keyPair.PrivateKey)
+		if err != nil {
+			t.Fatalf("Failed to marshal PKCS#8: %v", err)
+		}
+
+		// The structure must be a plain PrivateKeyInfo
+		var info struct {
+			Version    int
+			Algo       pkix.AlgorithmIdentifier
+			PrivateKey []byte
+		}
+		if _, err := asn1.Unmarshal(der, &info); err != nil {
+			t.Fatalf("DER is not a PrivateKeyInfo: %v", err)
+		}
+		if info.Version != 0 || !info.Algo.Algorithm.Equal(falconOIDs[logN]) || !bytes.Equal(info.PrivateKey, keyPair.PrivateKey) {
+			t.Fatalf("Unexpected PrivateKeyInfo contents: %+v", info)
+		}
+
+		parsed, err := ParsePKCS8PrivateKey(der)
+		if err != nil {
+			t.Fatalf("Failed to parse PKCS#8: %v", err)
+		}
+		if !bytes.Equal(parsed, keyPair.PrivateKey) {
+			t.Fatal("Round-tripped private key differs")
+		}
+	}
+}
+
+func TestPKCS8Errors(t *testing.T) {
+	k512, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	small, err := GenerateKeyPair(8)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	if _, err := MarshalPKCS8PrivateKey(small.PrivateKey); err == nil {
+		t.Fatal("Expected error for a degree without an OID")
+	}
+	if _, err := MarshalPKCS8PrivateKey(k512.PublicKey); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("Expected ErrBadFormat for a public key, got %v", err)
+	}
+
+	der, err := MarshalPKCS8PrivateKey(k512.PrivateKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal PKCS#8: %v", err)
+	}
+	mislabeled, err := asn1.Marshal(pkcs8{
+		Algo:       pkix.AlgorithmIdentifier{Algorithm: falconOIDs[10]},
+		PrivateKey: k512.PrivateKey,
+	})
+	if err != nil {
+		t.Fatalf("Failed to build test DER: %v", err)
+	}
+	unknown, err := asn1.Marshal(pkcs8{
+		Algo:       pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 3, 101, 112}},
+		PrivateKey: k512.PrivateKey,
+	})
+	if err != nil {
+		t.Fatalf("Failed to build test DER: %v", err)
+	}
+
+	bad := map[string][]byte{
+		"Empty":      nil,
+		"Truncated":  der[:len(der)-1],
+		"Trailing":   append(append([]byte{}, der...), 0),
+		"Mislabeled": mislabeled,
+		"UnknownOID": unknown,
+	}
+	for name, input := range bad {
+		if _, err := ParsePKCS8PrivateKey(input); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
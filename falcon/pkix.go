@@ -0,0 +1,80 @@
+package falcon
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+)
+
+// subjectPublicKeyInfo is the SubjectPublicKeyInfo structure of RFC 5280
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// MarshalPKIXPublicKey encodes a Falcon-512 or Falcon-1024 public key as
+// a DER SubjectPublicKeyInfo. The algorithm identifier carries the Falcon
+// OID for the key's degree and, as its parameters, logN as an INTEGER so
+// that parsers can size buffers without looking at the key. The bit
+// string holds the standard Falcon public key encoding.
+func MarshalPKIXPublicKey(publicKey []byte) ([]byte, error) {
+	logN, err := checkPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	oid, err := falconOID(logN)
+	if err != nil {
+		return nil, err
+	}
+	params, err := asn1.Marshal(int(logN))
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oid,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+		PublicKey: asn1.BitString{Bytes: publicKey, BitLength: 8 * len(publicKey)},
+	})
+}
+
+// ParsePKIXPublicKey decodes a DER SubjectPublicKeyInfo holding a Falcon
+// public key and returns the encoded key. The OID, the logN parameter and
+// the key's own degree must all agree.
+func ParsePKIXPublicKey(der []byte) ([]byte, error) {
+	var info subjectPublicKeyInfo
+	rest, err := asn1.Unmarshal(der, &info)
+	if err != nil {
+		return nil, fmt.Errorf("malformed SubjectPublicKeyInfo: %w", err)
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("trailing data after SubjectPublicKeyInfo")
+	}
+	oidLogN, err := falconOIDLogN(info.Algorithm.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	var paramLogN int
+	rest, err = asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &paramLogN)
+	if err != nil || len(rest) > 0 {
+		return nil, errors.New("Falcon algorithm parameters must be a logN INTEGER")
+	}
+	if paramLogN != int(oidLogN) {
+		return nil, fmt.Errorf("algorithm parameters give logN %d but the OID is Falcon-%d", paramLogN, 1<<oidLogN)
+	}
+
+	if info.PublicKey.BitLength%8 != 0 {
+		return nil, errors.New("public key bit string is not a whole number of bytes")
+	}
+	logN, err := checkPublicKey(info.PublicKey.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key in SubjectPublicKeyInfo: %w", err)
+	}
+	if logN != oidLogN {
+		return nil, fmt.Errorf("SubjectPublicKeyInfo algorithm is Falcon-%d but the key is Falcon-%d", 1<<oidLogN, 1<<logN)
+	}
+	return info.PublicKey.Bytes, nil
+}
@@ -0,0 +1,80 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"testing"
+)
+
+func TestPKIXRoundTrip(t *testing.T) {
+	for _, logN := range []uint{9, 10} {
+		keyPair, err := GenerateKeyPair(logN)
+		if err != nil {
+			t.Fatalf("Failed to generate key pair: %v", err)
+		}
+
+		der, err := MarshalPKIXPublicKey(keyPair.PublicKey)
+		if err != nil {
+			t.Fatalf("Failed to marshal SubjectPublicKeyInfo: %v", err)
+		}
+
+		// crypto/x509 must get as far as rejecting the unknown algorithm,
+		// which means the outer structure parsed
+		if _, err := x509.ParsePKIXPublicKey(der); err == nil || !bytes.Contains([]byte(err.Error()), []byte("unknown public key algorithm")) {
+			t.Fatalf("crypto/x509 did not parse the structure: %v", err)
+		}
+
+		parsed, err := ParsePKIXPublicKey(der)
+		if err != nil {
+			t.Fatalf("Failed to parse SubjectPublicKeyInfo: %v", err)
+		}
+		if !bytes.Equal(parsed, keyPair.PublicKey) {
+			t.Fatal("Round-tripped public key differs")
+		}
+	}
+}
+
+func TestPKIXErrors(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	if _, err := MarshalPKIXPublicKey(keyPair.PrivateKey); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("Expected ErrBadFormat for a private key, got %v", err)
+	}
+
+	build := func(oid asn1.ObjectIdentifier, logN int) []byte {
+		params, _ := asn1.Marshal(logN)
+		der, err := asn1.Marshal(subjectPublicKeyInfo{
+			Algorithm: pkix.AlgorithmIdentifier{Algorithm: oid, Parameters: asn1.RawValue{FullBytes: params}},
+			PublicKey: asn1.BitString{Bytes: keyPair.PublicKey, BitLength: 8 * len(keyPair.PublicKey)},
+		})
+		if err != nil {
+			t.Fatalf("Failed to build test DER: %v", err)
+		}
+		return der
+	}
+	der := build(falconOIDs[9], 9)
+
+	bad := map[string][]byte{
+		"Empty":      nil,
+		"Truncated":  der[:len(der)-1],
+		"Trailing":   append(append([]byte{}, der...), 0),
+		"WrongParam": build(falconOIDs[9], 10),
+		"WrongOID":   build(falconOIDs[10], 10),
+		"UnknownOID": build(asn1.ObjectIdentifier{1, 3, 101, 112}, 9),
+	}
+	for name, input := range bad {
+		if _, err := ParsePKIXPublicKey(input); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if _, err := ParsePKIXPublicKey(der); err != nil {
+		t.Fatalf("Well-formed DER rejected: %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package falcon
+
+import "fmt"
+
+// PolicyFunc decides whether a signature may be accepted before any
+// cryptographic work is done. It receives the degree of the public key,
+// the type of the signature and the public key fingerprint, and returns
+// a non-nil error to reject.
+type PolicyFunc func(logN, sigType int, fp []byte) error
+
+// VerifyWithPolicy evaluates policy against the public key metadata and,
+// only if the policy accepts, verifies the signature. With sigType 0 the
+// policy sees the type detected by DetectSigType, never 0; otherwise it
+// sees sigType, which the signature must then match to verify.
+func VerifyWithPolicy(signature, message, publicKey []byte, sigType int, policy PolicyFunc) error {
+	if policy == nil {
+		return newError(ErrBadArg, "nil policy")
+	}
+
+	logN, err := GetLogN(publicKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+
+	fp, err := Fingerprint(publicKey)
+	if err != nil {
+		return err
+	}
+
+	actualType := sigType
+	if actualType == 0 {
+		if actualType, err = DetectSigType(signature); err != nil {
+			return fmt.Errorf("malformed signature: %w", err)
+		}
+	}
+
+	if err := policy(logN, actualType, fp); err != nil {
+		return fmt.Errorf("rejected by policy: %w", err)
+	}
+
+	return Verify(signature, message, publicKey, SignatureType(sigType))
+}
@@ -0,0 +1,96 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestVerifyWithPolicy(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	message := []byte("policy test message")
+	signature, err := Sign(message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	fp, err := Fingerprint(keyPair.PublicKey)
+	if err != nil {
+		t.Fatalf("Failed to compute fingerprint: %v", err)
+	}
+
+	errDenied := errors.New("denied")
+
+	t.Run("Accept", func(t *testing.T) {
+		policy := func(logN, sigType int, keyFP []byte) error {
+			if logN != 9 || sigType != SigCompressed || !bytes.Equal(keyFP, fp) {
+				t.Errorf("Unexpected policy input: logN=%d sigType=%d", logN, sigType)
+			}
+			return nil
+		}
+		if err := VerifyWithPolicy(signature, message, keyPair.PublicKey, SigCompressed, policy); err != nil {
+			t.Fatalf("Verification failed: %v", err)
+		}
+	})
+
+	t.Run("AutoDetect", func(t *testing.T) {
+		ct, err := Sign(message, keyPair.PrivateKey, SigCT)
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		compressedOnly := func(logN, sigType int, keyFP []byte) error {
+			if sigType != SigCompressed {
+				return errDenied
+			}
+			return nil
+		}
+		if err := VerifyWithPolicy(signature, message, keyPair.PublicKey, 0, compressedOnly); err != nil {
+			t.Fatalf("Verification failed: %v", err)
+		}
+		err = VerifyWithPolicy(ct, message, keyPair.PublicKey, 0, compressedOnly)
+		if !errors.Is(err, errDenied) {
+			t.Fatalf("Expected policy rejection of a CT signature, got %v", err)
+		}
+	})
+
+	t.Run("RejectDegree", func(t *testing.T) {
+		policy := func(logN, sigType int, keyFP []byte) error {
+			if logN < 10 {
+				return errDenied
+			}
+			return nil
+		}
+		err := VerifyWithPolicy(signature, message, keyPair.PublicKey, SigCompressed, policy)
+		if !errors.Is(err, errDenied) {
+			t.Fatalf("Expected policy rejection, got %v", err)
+		}
+	})
+
+	t.Run("RejectFingerprint", func(t *testing.T) {
+		other, err := GenerateKeyPair(9)
+		if err != nil {
+			t.Fatalf("Failed to generate key pair: %v", err)
+		}
+		allowed, err := Fingerprint(other.PublicKey)
+		if err != nil {
+			t.Fatalf("Failed to compute fingerprint: %v", err)
+		}
+
+		policy := func(logN, sigType int, keyFP []byte) error {
+			if !bytes.Equal(keyFP, allowed) {
+				return errDenied
+			}
+			return nil
+		}
+		err = VerifyWithPolicy(signature, message, keyPair.PublicKey, SigCompressed, policy)
+		if !errors.Is(err, errDenied) {
+			t.Fatalf("Expected policy rejection, got %v", err)
+		}
+	})
+}
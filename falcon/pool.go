@@ -0,0 +1,106 @@
+package falcon
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrPoolClosed is delivered for items submitted to a closed VerifyPool
+var ErrPoolClosed = errors.New("verify pool closed")
+
+// VerifyItem is a single signature verification request
+type VerifyItem struct {
+	Signature []byte
+	Message   []byte
+	PublicKey []byte
+	SigType   int
+}
+
+// verifyJob pairs an item with the channel its result is sent on
+type verifyJob struct {
+	item   VerifyItem
+	result chan error
+}
+
+// VerifyPool verifies signatures on a fixed set of worker goroutines.
+// Each worker keeps its own scratch buffer, so steady-state verification
+// does not allocate one per call.
+type VerifyPool struct {
+	mu     sync.RWMutex
+	closed bool
+	jobs   chan verifyJob
+	wg     sync.WaitGroup
+}
+
+// NewVerifyPool starts a pool with the given number of workers and a
+// queue holding up to queueSize pending items. Values below 1 are
+// raised to 1 worker and an unbuffered queue respectively.
+func NewVerifyPool(workers, queueSize int) *VerifyPool {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	p := &VerifyPool{jobs: make(chan verifyJob, queueSize)}
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.worker()
+	}
+	return p
+}
+
+// worker verifies queued items until the pool is closed
+func (p *VerifyPool) worker() {
+	defer p.wg.Done()
+
+	// Large enough for every supported degree
+	tmp := make([]byte, tmpSizeVerify(10))
+	for job := range p.jobs {
+		job.result <- verifyItem(job.item, tmp)
+	}
+}
+
+// verifyItem checks and verifies item like Verify, using tmp as the
+// scratch buffer
+func verifyItem(item VerifyItem, tmp []byte) error {
+	logN, err := checkPublicKey(item.PublicKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	if _, err := checkSignature(item.Signature, item.SigType); err != nil {
+		return fmt.Errorf("malformed signature: %w", err)
+	}
+	return verifyWithTmp(item.Signature, item.Message, item.PublicKey, item.SigType, tmp[:tmpSizeVerify(logN)])
+}
+
+// Submit queues item for verification and returns a channel that
+// receives its result exactly once. When the queue is full Submit
+// blocks until a worker frees a slot, which applies backpressure to the
+// producer.
+func (p *VerifyPool) Submit(item VerifyItem) <-chan error {
+	result := make(chan error, 1)
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		result <- ErrPoolClosed
+		return result
+	}
+	p.jobs <- verifyJob{item: item, result: result}
+	return result
+}
+
+// Close stops accepting items, lets the workers finish the queued ones
+// and waits for them to exit. It is safe to call more than once.
+func (p *VerifyPool) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.jobs)
+	}
+	p.mu.Unlock()
+	p.wg.Wait()
+}
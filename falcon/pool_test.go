@@ -0,0 +1,68 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestVerifyPool(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	small, err := GenerateKeyPair(4)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	pool := NewVerifyPool(3, 2)
+
+	const n = 40
+	results := make([]<-chan error, n)
+	valid := make([]bool, n)
+	for i := 0; i < n; i++ {
+		kp := keyPair
+		if i%5 == 0 {
+			kp = small
+		}
+		message := []byte(fmt.Sprintf("message %d", i))
+		signature, err := Sign(message, kp.PrivateKey, SigCompressed)
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		valid[i] = i%3 != 0
+		if !valid[i] {
+			message = append(message, '!')
+		}
+		results[i] = pool.Submit(VerifyItem{
+			Signature: signature,
+			Message:   message,
+			PublicKey: kp.PublicKey,
+			SigType:   SigCompressed,
+		})
+	}
+
+	for i, ch := range results {
+		err := <-ch
+		if valid[i] && err != nil {
+			t.Fatalf("Item %d failed verification: %v", i, err)
+		}
+		if !valid[i] && err == nil {
+			t.Fatalf("Item %d verified with a modified message", i)
+		}
+	}
+
+	malformed := <-pool.Submit(VerifyItem{Signature: []byte{0x39}, PublicKey: keyPair.PublicKey, SigType: SigCompressed})
+	if !errors.Is(malformed, ErrBadFormat) {
+		t.Fatalf("Expected ErrBadFormat for malformed signature, got %v", malformed)
+	}
+
+	pool.Close()
+	pool.Close()
+	if err := <-pool.Submit(VerifyItem{}); !errors.Is(err, ErrPoolClosed) {
+		t.Fatalf("Expected ErrPoolClosed after Close, got %v", err)
+	}
+}
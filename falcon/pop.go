@@ -0,0 +1,24 @@
+package falcon
+
+import "fmt"
+
+// popDomain prefixes the public key in a proof-of-possession challenge
+var popDomain = []byte("falcon-pop")
+
+// ProofOfPossession proves ownership of a private key by signing
+// "falcon-pop" || publicKey, where publicKey is derived from privateKey.
+// Binding the public key into the signed data prevents a proof made for
+// one key from being presented for another.
+func ProofOfPossession(privateKey []byte, sigType int) ([]byte, error) {
+	publicKey, err := derivePublicKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive public key: %w", err)
+	}
+	return Sign(append(append([]byte{}, popDomain...), publicKey...), privateKey, SignatureType(sigType))
+}
+
+// VerifyProofOfPossession checks a proof produced by ProofOfPossession
+// for publicKey
+func VerifyProofOfPossession(pop, publicKey []byte, sigType int) error {
+	return Verify(pop, append(append([]byte{}, popDomain...), publicKey...), publicKey, SignatureType(sigType))
+}
@@ -0,0 +1,37 @@
+//go:build cgo
+
+package falcon
+
+import "testing"
+
+func TestProofOfPossession(t *testing.T) {
+	a, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	b, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	pop, err := ProofOfPossession(a.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to create proof of possession: %v", err)
+	}
+
+	if err := VerifyProofOfPossession(pop, a.PublicKey, SigCompressed); err != nil {
+		t.Fatalf("Proof of possession verification failed: %v", err)
+	}
+	if err := VerifyProofOfPossession(pop, b.PublicKey, SigCompressed); err == nil {
+		t.Fatal("Proof made for one key verified under another")
+	}
+
+	// A plain signature over the public key is not a valid proof
+	plain, err := Sign(a.PublicKey, a.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	if err := VerifyProofOfPossession(plain, a.PublicKey, SigCompressed); err == nil {
+		t.Fatal("Plain signature accepted as proof of possession")
+	}
+}
@@ -0,0 +1,62 @@
+package falcon
+
+import "fmt"
+
+// PrecomputedKey is a public key decoded and converted to NTT form once,
+// so repeated verifications against it skip that step. It is safe for
+// concurrent use.
+type PrecomputedKey struct {
+	logN      uint
+	publicKey []byte
+	h         []uint16
+}
+
+// PrecomputePublicKey decodes publicKey and stores its NTT form
+func PrecomputePublicKey(publicKey []byte) (*PrecomputedKey, error) {
+	logN, err := checkPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	h, err := nttPublicKey(publicKey, logN)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	return &PrecomputedKey{
+		logN:      logN,
+		publicKey: append([]byte{}, publicKey...),
+		h:         h,
+	}, nil
+}
+
+// PublicKey returns the encoded public key
+func (k *PrecomputedKey) PublicKey() []byte {
+	return append([]byte{}, k.publicKey...)
+}
+
+// Verify verifies signature over message like Verify does for the
+// encoded key, with identical results
+func (k *PrecomputedKey) Verify(signature, message []byte, sigType int) error {
+	return k.verifyWithTmp(signature, message, sigType, make([]byte, tmpSizeVerify(k.logN)))
+}
+
+// verifyWithTmp is Verify using a caller-provided scratch buffer of at
+// least tmpSizeVerify(k.logN) bytes
+func (k *PrecomputedKey) verifyWithTmp(signature, message []byte, sigType int, tmp []byte) error {
+	logN, err := checkSignature(signature, sigType)
+	if err != nil {
+		return fmt.Errorf("malformed signature: %w", err)
+	}
+	if logN != k.logN {
+		return ErrBadSignature
+	}
+
+	hashData := &PRNGContext{}
+	if err := verifyStart(hashData, signature); err != nil {
+		return err
+	}
+	hashData.Inject(message)
+
+	ct := signature[0]&0xF0 == headerSigCT
+	padded := sigType == SigPadded || (sigType == 0 && !ct && len(signature) == sigPaddedSize(logN))
+	return verifyNTT(signature, ct, padded, k.h, logN, hashData, tmp[:tmpSizeVerify(logN)])
+}
@@ -0,0 +1,68 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrecomputedKey(t *testing.T) {
+	for _, logN := range []uint{2, 9, 10} {
+		keyPair, err := GenerateKeyPair(logN)
+		if err != nil {
+			t.Fatalf("Failed to generate key pair: %v", err)
+		}
+		key, err := PrecomputePublicKey(keyPair.PublicKey)
+		if err != nil {
+			t.Fatalf("Failed to precompute public key: %v", err)
+		}
+		if !bytes.Equal(key.PublicKey(), keyPair.PublicKey) {
+			t.Fatal("Precomputed key does not return the encoded key")
+		}
+
+		message := []byte("precomputed")
+		for _, sigType := range []int{SigCompressed, SigPadded, SigCT} {
+			signature, err := Sign(message, keyPair.PrivateKey, SignatureType(sigType))
+			if err != nil {
+				t.Fatalf("Failed to sign message: %v", err)
+			}
+
+			for _, verifyType := range []int{sigType, 0} {
+				if err := key.Verify(signature, message, verifyType); err != nil {
+					t.Fatalf("logN %d type %d/%d: verification failed: %v", logN, sigType, verifyType, err)
+				}
+			}
+			if err := key.Verify(signature, []byte("other"), sigType); err == nil {
+				t.Fatalf("logN %d type %d: verification succeeded for modified message", logN, sigType)
+			}
+
+			// Results must agree with plain Verify on corrupted input
+			for _, i := range []int{1, 45, len(signature) - 1} {
+				corrupted := append([]byte{}, signature...)
+				corrupted[i] ^= 0x10
+				plain := Verify(corrupted, message, keyPair.PublicKey, SignatureType(sigType))
+				pre := key.Verify(corrupted, message, sigType)
+				if (plain == nil) != (pre == nil) {
+					t.Fatalf("logN %d type %d byte %d: Verify=%v, precomputed=%v", logN, sigType, i, plain, pre)
+				}
+			}
+		}
+
+		other, err := GenerateKeyPair(logN%10 + 1)
+		if err != nil {
+			t.Fatalf("Failed to generate key pair: %v", err)
+		}
+		signature, err := Sign(message, other.PrivateKey, SigCompressed)
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		if err := key.Verify(signature, message, SigCompressed); err == nil {
+			t.Fatal("Verification succeeded for a signature of another degree")
+		}
+	}
+
+	if _, err := PrecomputePublicKey([]byte{0x09, 1, 2}); err == nil {
+		t.Fatal("Expected error for truncated public key")
+	}
+}
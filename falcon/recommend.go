@@ -0,0 +1,18 @@
+package falcon
+
+// RecommendSignatureType suggests a signature type for the given
+// priority and explains the choice. priority is "size", "speed" or
+// "constant-time"; any other value yields type 0 and an explanation
+// listing the accepted priorities. The advice is informational only.
+func RecommendSignatureType(priority string) (int, string) {
+	switch priority {
+	case "size":
+		return SigCompressed, "compressed: the smallest signatures, with a variable length up to a per-degree maximum"
+	case "speed":
+		return SigPadded, "padded: compressed encoding zero-padded to a fixed length, so buffers and framing need no length field; a middle ground between size and constant-time handling"
+	case "constant-time":
+		return SigCT, "constant-time: fixed-length encoding decoded without data-dependent branches, at the cost of larger signatures"
+	default:
+		return 0, `unknown priority; use "size", "speed" or "constant-time"`
+	}
+}
@@ -0,0 +1,27 @@
+//go:build cgo
+
+package falcon
+
+import "testing"
+
+func TestRecommendSignatureType(t *testing.T) {
+	tests := []struct {
+		priority string
+		want     int
+	}{
+		{"size", SigCompressed},
+		{"speed", SigPadded},
+		{"constant-time", SigCT},
+		{"fastest", 0},
+	}
+
+	for _, tt := range tests {
+		got, why := RecommendSignatureType(tt.priority)
+		if got != tt.want {
+			t.Errorf("RecommendSignatureType(%q) = %d, want %d", tt.priority, got, tt.want)
+		}
+		if why == "" {
+			t.Errorf("RecommendSignatureType(%q) returned no explanation", tt.priority)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package falcon
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrReplay is returned by VerifyNoReplay for a signature whose nonce
+// has already been accepted
+var ErrReplay = errors.New("signature nonce already seen")
+
+// NonceStore records the nonces of accepted signatures
+type NonceStore interface {
+	// Add records nonce and reports whether it was new. The check and
+	// the insert must be a single atomic step, so that concurrent calls
+	// with the same nonce see true exactly once.
+	Add(nonce []byte) bool
+}
+
+// MemoryNonceStore is an unbounded in-memory NonceStore. It is safe for
+// concurrent use.
+type MemoryNonceStore struct {
+	mu   sync.Mutex
+	seen map[string]struct{}
+}
+
+// NewMemoryNonceStore creates an empty MemoryNonceStore
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{seen: make(map[string]struct{})}
+}
+
+// Has reports whether nonce has been recorded
+func (s *MemoryNonceStore) Has(nonce []byte) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.seen[string(nonce)]
+	return ok
+}
+
+// Add records nonce and reports whether it was not recorded before
+func (s *MemoryNonceStore) Add(nonce []byte) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.seen[string(nonce)]; ok {
+		return false
+	}
+	s.seen[string(nonce)] = struct{}{}
+	return true
+}
+
+// VerifyNoReplay verifies signature and rejects it with ErrReplay if its
+// 40-byte nonce is already in seen. The nonce is recorded only after a
+// successful verification, so invalid signatures cannot poison the
+// store, and recording it is the replay check itself: of several
+// concurrent verifications of the same signature, exactly one succeeds.
+func VerifyNoReplay(signature, message, publicKey []byte, sigType int, seen NonceStore) error {
+	if _, err := checkSignature(signature, sigType); err != nil {
+		return fmt.Errorf("malformed signature: %w", err)
+	}
+
+	if err := Verify(signature, message, publicKey, SignatureType(sigType)); err != nil {
+		return err
+	}
+	if !seen.Add(append([]byte{}, signature[1:1+nonceSize]...)) {
+		return ErrReplay
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestVerifyNoReplay(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	store := NewMemoryNonceStore()
+
+	message := []byte("transfer 10")
+	signature, err := Sign(message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	// An invalid attempt must not record the nonce
+	if err := VerifyNoReplay(signature, []byte("transfer 99"), keyPair.PublicKey, SigCompressed, store); err == nil {
+		t.Fatal("Verification succeeded for modified message")
+	}
+
+	if err := VerifyNoReplay(signature, message, keyPair.PublicKey, SigCompressed, store); err != nil {
+		t.Fatalf("First verification failed: %v", err)
+	}
+	if err := VerifyNoReplay(signature, message, keyPair.PublicKey, SigCompressed, store); !errors.Is(err, ErrReplay) {
+		t.Fatalf("Expected ErrReplay for replayed signature, got %v", err)
+	}
+
+	// A fresh signature of the same message has a new nonce
+	again, err := Sign(message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	if err := VerifyNoReplay(again, message, keyPair.PublicKey, SigCompressed, store); err != nil {
+		t.Fatalf("Verification of a new signature failed: %v", err)
+	}
+
+	if err := VerifyNoReplay(signature[:20], message, keyPair.PublicKey, SigCompressed, store); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("Expected ErrBadFormat for truncated signature, got %v", err)
+	}
+}
+
+func TestVerifyNoReplayConcurrent(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	message := []byte("transfer 10")
+	signature, err := Sign(message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	store := NewMemoryNonceStore()
+	const workers = 16
+	var accepted atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := VerifyNoReplay(signature, message, keyPair.PublicKey, SigCompressed, store)
+			switch {
+			case err == nil:
+				accepted.Add(1)
+			case !errors.Is(err, ErrReplay):
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := accepted.Load(); n != 1 {
+		t.Fatalf("Signature accepted %d times, want exactly once", n)
+	}
+}
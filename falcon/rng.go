@@ -0,0 +1,66 @@
+package falcon
+
+import "fmt"
+
+// prngBufferSize is the size of the Extract output buffer, one SHAKE256
+// block
+const prngBufferSize = 136
+
+// maxSystemRNGAttempts bounds how many times seeding from the system
+// RNG is tried before an operation fails
+const maxSystemRNGAttempts = 3
+
+// seedFromSystem seeds a PRNG from the operating system; tests replace
+// it to simulate entropy failures
+var seedFromSystem = (*PRNGContext).InitFromSystem
+
+// newSystemPRNG returns a PRNG seeded from the system RNG. Seeding
+// failures are usually transient, so it is retried a few times before
+// giving up.
+func newSystemPRNG() (*PRNGContext, error) {
+	var err error
+	for attempt := 1; attempt <= maxSystemRNGAttempts; attempt++ {
+		rng := &PRNGContext{}
+		if err = seedFromSystem(rng); err == nil {
+			return rng, nil
+		}
+		logWarn("seeding from the system RNG failed", "attempt", attempt, "error", err)
+	}
+	return nil, fmt.Errorf("system RNG failed after %d attempts, check that the OS entropy source (getrandom or /dev/urandom) is available: %w",
+		maxSystemRNGAttempts, err)
+}
+
+// Errors returned by PRNGContext.Write and Read when the context is in
+// the wrong mode
+var (
+	ErrContextFlipped    = newError(ErrBadArg, "PRNG context already flipped to output mode")
+	ErrContextNotFlipped = newError(ErrBadArg, "PRNG context not flipped to output mode")
+)
+
+// Write absorbs data, making a context set up with Init an io.Writer for
+// use as a SHAKE256 XOF. It fails with ErrContextFlipped once the context
+// has been flipped to output mode.
+func (p *PRNGContext) Write(data []byte) (int, error) {
+	if p.flipped {
+		return 0, ErrContextFlipped
+	}
+	p.Inject(data)
+	return len(data), nil
+}
+
+// Read squeezes len(out) bytes, making a flipped context an io.Reader
+// that never ends. It fails with ErrContextNotFlipped until Flip has been
+// called or the context was seeded with InitFromSeed or InitFromSystem.
+func (p *PRNGContext) Read(out []byte) (int, error) {
+	if !p.flipped {
+		return 0, ErrContextNotFlipped
+	}
+	p.Extract(out)
+	return len(out), nil
+}
+
+// resetBuffer discards buffered output, wiping it
+func (p *PRNGContext) resetBuffer() {
+	SecureZero(p.buf[:])
+	p.bufPos, p.bufLen = 0, 0
+}
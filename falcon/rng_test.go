@@ -0,0 +1,226 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSystemRNGRetry(t *testing.T) {
+	defer func() { seedFromSystem = (*PRNGContext).InitFromSystem }()
+
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	// Transient failures are retried and the operation succeeds
+	calls := 0
+	seedFromSystem = func(p *PRNGContext) error {
+		calls++
+		if calls < maxSystemRNGAttempts {
+			return falconError(ErrRandom)
+		}
+		return p.InitFromSystem()
+	}
+	if _, err := Sign([]byte("retry"), keyPair.PrivateKey, SigCompressed); err != nil {
+		t.Fatalf("Failed to sign after transient RNG failures: %v", err)
+	}
+	if calls != maxSystemRNGAttempts {
+		t.Fatalf("Expected %d seeding attempts, got %d", maxSystemRNGAttempts, calls)
+	}
+
+	// A persistent failure gives up after the bounded number of attempts
+	calls = 0
+	rngErr := falconError(ErrRandom)
+	seedFromSystem = func(p *PRNGContext) error {
+		calls++
+		return rngErr
+	}
+	_, err = GenerateKeyPair(9)
+	if err == nil {
+		t.Fatal("Expected error when the system RNG keeps failing")
+	}
+	if calls != maxSystemRNGAttempts {
+		t.Fatalf("Expected %d seeding attempts, got %d", maxSystemRNGAttempts, calls)
+	}
+	if !errors.Is(err, rngErr) || !strings.Contains(err.Error(), "entropy source") {
+		t.Fatalf("Expected wrapped RNG error with guidance, got %v", err)
+	}
+	if _, err := Sign([]byte("retry"), keyPair.PrivateKey, SigCompressed); !errors.Is(err, rngErr) {
+		t.Fatalf("Expected wrapped RNG error from Sign, got %v", err)
+	}
+}
+
+func TestPRNGContextReadWriter(t *testing.T) {
+	var _ io.ReadWriter = (*PRNGContext)(nil)
+
+	squeeze := func(chunks ...string) []byte {
+		var p PRNGContext
+		p.Init()
+		for _, c := range chunks {
+			if _, err := io.WriteString(&p, c); err != nil {
+				t.Fatalf("Failed to write: %v", err)
+			}
+		}
+		if _, err := p.Read(make([]byte, 1)); !errors.Is(err, ErrContextNotFlipped) {
+			t.Fatalf("Expected ErrContextNotFlipped, got %v", err)
+		}
+		p.Flip()
+		if _, err := p.Write([]byte("late")); !errors.Is(err, ErrContextFlipped) {
+			t.Fatalf("Expected ErrContextFlipped, got %v", err)
+		}
+		out := make([]byte, 100)
+		if _, err := io.ReadFull(&p, out); err != nil {
+			t.Fatalf("Failed to read: %v", err)
+		}
+		return out
+	}
+
+	// Output depends only on the absorbed bytes, not how they were written
+	a := squeeze("hello, ", "world")
+	b := squeeze("hello, world")
+	if !bytes.Equal(a, b) {
+		t.Fatal("Reader output depends on write boundaries")
+	}
+	if !bytes.Equal(a, shake256(100, []byte("hello, world"))) {
+		t.Fatal("Reader output differs from SHAKE256")
+	}
+
+	// Seeded contexts start in output mode
+	var seeded PRNGContext
+	seeded.InitFromSeed([]byte("seed"))
+	if _, err := seeded.Read(make([]byte, 8)); err != nil {
+		t.Fatalf("Failed to read from seeded context: %v", err)
+	}
+}
+
+func TestPRNGExtractSplits(t *testing.T) {
+	const total = 1000
+	var ref PRNGContext
+	ref.InitFromSeed([]byte("split seed"))
+	want := make([]byte, total)
+	ref.Extract(want)
+
+	for _, split := range []int{1, 3, 7, 64, 135, 136, 137, 500} {
+		var p PRNGContext
+		p.InitFromSeed([]byte("split seed"))
+		got := make([]byte, 0, total)
+		for len(got) < total {
+			n := split
+			if n > total-len(got) {
+				n = total - len(got)
+			}
+			chunk := make([]byte, n)
+			p.Extract(chunk)
+			got = append(got, chunk...)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("Extract in chunks of %d differs from one Extract", split)
+		}
+	}
+
+	// Mixed sizes, including ones that straddle the buffer
+	var p PRNGContext
+	p.InitFromSeed([]byte("split seed"))
+	var got []byte
+	for _, n := range []int{5, 200, 1, 136, 10, 300, 348} {
+		chunk := make([]byte, n)
+		p.Extract(chunk)
+		got = append(got, chunk...)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatal("Extract with mixed sizes differs from one Extract")
+	}
+}
+
+func TestPRNGContextWithIOHelpers(t *testing.T) {
+	var p PRNGContext
+	p.Init()
+	if _, err := io.Copy(&p, strings.NewReader("absorbed through io.Copy")); err != nil {
+		t.Fatalf("io.Copy into context failed: %v", err)
+	}
+	p.Flip()
+
+	out := make([]byte, 64)
+	if _, err := io.ReadFull(bufio.NewReader(&p), out); err != nil {
+		t.Fatalf("Reading through bufio failed: %v", err)
+	}
+	if !bytes.Equal(out, shake256(64, []byte("absorbed through io.Copy"))) {
+		t.Fatal("Output through io helpers differs from SHAKE256")
+	}
+
+	// Writing after Flip is an error, not a crash in C
+	if _, err := io.Copy(&p, strings.NewReader("late")); !errors.Is(err, ErrContextFlipped) {
+		t.Fatalf("Expected ErrContextFlipped, got %v", err)
+	}
+}
+
+// checkNonces fails unless every signature carries a nonzero nonce
+// distinct from the others. A PRNG read before it is flipped to output
+// mode yields all-zero nonces.
+func checkNonces(t *testing.T, what string, sigs [][]byte) {
+	t.Helper()
+	zero := make([]byte, nonceSize)
+	seen := make(map[string]bool)
+	for i, sig := range sigs {
+		nonce := sig[1 : 1+nonceSize]
+		if bytes.Equal(nonce, zero) {
+			t.Fatalf("%s: signature %d has an all-zero nonce", what, i)
+		}
+		if seen[string(nonce)] {
+			t.Fatalf("%s: signature %d repeats an earlier nonce", what, i)
+		}
+		seen[string(nonce)] = true
+	}
+}
+
+func TestPRNGNonces(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	// System-seeded PRNG
+	var sigs [][]byte
+	for i := 0; i < 3; i++ {
+		sig, err := Sign([]byte("message"), keyPair.PrivateKey, SigCompressed)
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		sigs = append(sigs, sig)
+	}
+	checkNonces(t, "Sign", sigs)
+
+	// Seed-derived PRNG
+	_, pairs, err := GenerateCorpus(bytes.Repeat([]byte{0x42}, 32), 9, 3, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to generate corpus: %v", err)
+	}
+	sigs = sigs[:0]
+	for _, p := range pairs {
+		sigs = append(sigs, p.Signature)
+	}
+	checkNonces(t, "GenerateCorpus", sigs)
+
+	// Raw output right after seeding
+	for _, init := range []func(*PRNGContext) error{
+		func(p *PRNGContext) error { p.InitFromSeed([]byte("seed")); return nil },
+		(*PRNGContext).InitFromSystem,
+	} {
+		rng := &PRNGContext{}
+		if err := init(rng); err != nil {
+			t.Fatalf("Failed to seed PRNG: %v", err)
+		}
+		out := make([]byte, nonceSize)
+		rng.Extract(out)
+		if bytes.Equal(out, make([]byte, nonceSize)) {
+			t.Fatal("Seeded PRNG produced all-zero output")
+		}
+	}
+}
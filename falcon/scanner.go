@@ -0,0 +1,153 @@
+package falcon
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrSignatureComplete is returned by SignatureScanner.Write for bytes
+// offered after the signature has been fully read
+var ErrSignatureComplete = errors.New("signature already complete")
+
+// SignatureScanner validates a signature of a known type and degree as
+// its bytes arrive, for example one at a time off the wire. It checks
+// the header as soon as it is seen and the body as soon as enough bytes
+// are present, and reports completion at the exact end of the
+// signature, including for the variable-length compressed format.
+type SignatureScanner struct {
+	sigType  int
+	logN     uint
+	maxLen   int
+	buf      []byte
+	complete bool
+	err      error
+}
+
+// NewSignatureScanner returns a scanner expecting a signature of the
+// given type and degree. Invalid arguments are reported by the first
+// call to Write or Complete.
+func NewSignatureScanner(sigType int, logN uint) *SignatureScanner {
+	s := &SignatureScanner{sigType: sigType, logN: logN}
+	if logN < 1 || logN > 10 {
+		s.err = errInvalidLogN
+		return s
+	}
+	maxLen, err := sigBufferSize(logN, sigType)
+	if err != nil {
+		s.err = err
+		return s
+	}
+	if err := checkCodec(); err != nil {
+		s.err = err
+		return s
+	}
+	s.maxLen = maxLen
+	s.buf = make([]byte, 0, maxLen)
+	return s
+}
+
+// Write consumes signature bytes. It stops at the end of the signature:
+// any bytes beyond it are not consumed and ErrSignatureComplete is
+// returned with the count of bytes that were. A framing error is
+// returned as soon as it is detected and is sticky.
+func (s *SignatureScanner) Write(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	if s.complete {
+		if len(p) == 0 {
+			return 0, nil
+		}
+		return 0, ErrSignatureComplete
+	}
+
+	start := len(s.buf)
+	take := s.maxLen - start
+	if take > len(p) {
+		take = len(p)
+	}
+	s.buf = append(s.buf, p[:take]...)
+
+	if start == 0 && len(s.buf) > 0 {
+		if err := s.checkHeader(); err != nil {
+			s.err = err
+			return 0, err
+		}
+	}
+	if err := s.checkBody(); err != nil {
+		s.err = err
+		return take, err
+	}
+
+	if s.complete && len(s.buf)-start < len(p) {
+		return len(s.buf) - start, ErrSignatureComplete
+	}
+	return take, nil
+}
+
+// checkHeader validates the first byte against the expected type and degree
+func (s *SignatureScanner) checkHeader() error {
+	want := byte(headerSigCompressed)
+	if s.sigType == SigCT {
+		want = headerSigCT
+	}
+	want |= byte(s.logN)
+	if s.buf[0] != want {
+		return fmt.Errorf("%w: header 0x%02x, want 0x%02x", ErrBadHeader, s.buf[0], want)
+	}
+	return nil
+}
+
+// checkBody tries to decode the body read so far and marks the
+// signature complete once it holds a full valid encoding
+func (s *SignatureScanner) checkBody() error {
+	if len(s.buf) <= 1+nonceSize {
+		return nil
+	}
+	body := s.buf[1+nonceSize:]
+
+	switch s.sigType {
+	case SigCompressed:
+		if v := decodeSigBody(body, s.logN, false); v > 0 {
+			// Drop anything read past the end of the encoding
+			s.buf = s.buf[:1+nonceSize+v]
+			s.complete = true
+			return nil
+		}
+	case SigPadded, SigCT:
+		if len(s.buf) < s.maxLen {
+			return nil
+		}
+		v := decodeSigBody(body, s.logN, s.sigType == SigCT)
+		if v == 0 {
+			return fmt.Errorf("%w: undecodable signature body", ErrBadFormat)
+		}
+		for _, b := range body[v:] {
+			if b != 0 {
+				return fmt.Errorf("%w: nonzero padding", ErrBadFormat)
+			}
+		}
+		s.complete = true
+		return nil
+	}
+
+	if len(s.buf) == s.maxLen {
+		return fmt.Errorf("%w: no valid encoding within %d bytes", ErrBadLength, s.maxLen)
+	}
+	return nil
+}
+
+// Complete reports whether a full well-formed signature has been read,
+// or the framing error that stopped the scanner
+func (s *SignatureScanner) Complete() (bool, error) {
+	return s.complete, s.err
+}
+
+// Signature returns a copy of the signature once Complete reports true,
+// and nil before that
+func (s *SignatureScanner) Signature() []byte {
+	if !s.complete {
+		return nil
+	}
+	return append([]byte{}, s.buf...)
+}
@@ -0,0 +1,90 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestSignatureScanner(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	message := []byte("scanned")
+
+	// Padded, one byte at a time
+	padded, err := Sign(message, keyPair.PrivateKey, SigPadded)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	s := NewSignatureScanner(SigPadded, 9)
+	for i, b := range padded {
+		if done, err := s.Complete(); done || err != nil {
+			t.Fatalf("Scanner done=%v err=%v after %d of %d bytes", done, err, i, len(padded))
+		}
+		if n, err := s.Write([]byte{b}); n != 1 || err != nil {
+			t.Fatalf("Write of byte %d returned %d, %v", i, n, err)
+		}
+	}
+	if done, err := s.Complete(); !done || err != nil {
+		t.Fatalf("Scanner not complete after full signature: done=%v err=%v", done, err)
+	}
+	if !bytes.Equal(s.Signature(), padded) {
+		t.Fatal("Scanned signature differs from input")
+	}
+	if _, err := s.Write([]byte{0}); !errors.Is(err, ErrSignatureComplete) {
+		t.Fatalf("Expected ErrSignatureComplete, got %v", err)
+	}
+
+	// Compressed, followed by unrelated bytes in the same write: the
+	// scanner must stop exactly at the end of the signature
+	compressed, err := Sign(message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	s = NewSignatureScanner(SigCompressed, 9)
+	n, err := s.Write(append(append([]byte{}, compressed...), 0xAA, 0xBB))
+	if n != len(compressed) || !errors.Is(err, ErrSignatureComplete) {
+		t.Fatalf("Expected %d bytes and ErrSignatureComplete, got %d, %v", len(compressed), n, err)
+	}
+	if !bytes.Equal(s.Signature(), compressed) {
+		t.Fatal("Scanned compressed signature differs from input")
+	}
+
+	// CT in a single write
+	ct, err := Sign(message, keyPair.PrivateKey, SigCT)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	s = NewSignatureScanner(SigCT, 9)
+	if n, err := s.Write(ct); n != len(ct) || err != nil {
+		t.Fatalf("Write returned %d, %v", n, err)
+	}
+	if done, _ := s.Complete(); !done {
+		t.Fatal("CT scanner not complete")
+	}
+
+	// A wrong header is rejected on the first byte
+	s = NewSignatureScanner(SigPadded, 10)
+	if _, err := s.Write(padded[:1]); !errors.Is(err, ErrBadHeader) {
+		t.Fatalf("Expected ErrBadHeader, got %v", err)
+	}
+	if _, err := s.Complete(); err == nil {
+		t.Fatal("Expected sticky error")
+	}
+
+	// Nonzero padding is rejected once the fixed length is reached
+	bad := append([]byte{}, padded...)
+	bad[len(bad)-1] ^= 0xFF
+	s = NewSignatureScanner(SigPadded, 9)
+	if _, err := s.Write(bad); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("Expected ErrBadFormat for corrupted padding, got %v", err)
+	}
+
+	if _, err := NewSignatureScanner(7, 9).Write(padded); err == nil {
+		t.Fatal("Expected error for invalid signature type")
+	}
+}
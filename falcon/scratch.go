@@ -0,0 +1,51 @@
+package falcon
+
+import "sync"
+
+// Scratch buffer kinds; each kind and degree has its own pool
+const (
+	scratchKeygen = iota
+	scratchSign
+	scratchVerify
+	numScratchKinds
+)
+
+// scratchPools recycles the tmp buffers handed to the C library, indexed
+// by kind and logN. Pointers to slices are pooled so that Put does not
+// allocate.
+var scratchPools [numScratchKinds][11]sync.Pool
+
+// scratchSize returns the buffer size for a kind and degree
+func scratchSize(kind int, logN uint) int {
+	switch kind {
+	case scratchKeygen:
+		return tmpSizeKeygen(logN)
+	case scratchSign:
+		return tmpSizeSignDyn(logN)
+	default:
+		return tmpSizeVerify(logN)
+	}
+}
+
+// getScratch returns a scratch buffer for a kind and degree along with
+// the function that wipes it and returns it to its pool. Buffers used
+// for key generation and signing hold secret intermediate values, so
+// every buffer is zeroized before it can be handed out again.
+func getScratch(kind int, logN uint) ([]byte, func()) {
+	if logN < 1 || logN > 10 {
+		// Unvalidated degrees from hostile input are left for C to
+		// reject; they are not worth pooling
+		b := make([]byte, scratchSize(kind, logN))
+		return b, func() { SecureZero(b) }
+	}
+	pool := &scratchPools[kind][logN]
+	buf, ok := pool.Get().(*[]byte)
+	if !ok {
+		b := make([]byte, scratchSize(kind, logN))
+		buf = &b
+	}
+	return *buf, func() {
+		SecureZero(*buf)
+		pool.Put(buf)
+	}
+}
@@ -0,0 +1,36 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestScratchPool(t *testing.T) {
+	for kind := 0; kind < numScratchKinds; kind++ {
+		buf, release := getScratch(kind, 9)
+		if len(buf) != scratchSize(kind, 9) {
+			t.Fatalf("Kind %d: got %d bytes, want %d", kind, len(buf), scratchSize(kind, 9))
+		}
+		for i := range buf {
+			buf[i] = 0xA5
+		}
+		release()
+
+		// Whether or not the pool hands back the same buffer, it must be
+		// clean
+		buf, release = getScratch(kind, 9)
+		if !bytes.Equal(buf, make([]byte, len(buf))) {
+			t.Fatalf("Kind %d: pooled buffer was not zeroized", kind)
+		}
+		release()
+	}
+
+	// Degrees outside the pools still get a buffer
+	buf, release := getScratch(scratchVerify, 15)
+	if len(buf) != tmpSizeVerify(15) {
+		t.Fatalf("Got %d bytes for logN 15", len(buf))
+	}
+	release()
+}
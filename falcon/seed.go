@@ -0,0 +1,143 @@
+package falcon
+
+import (
+	"fmt"
+	"io"
+)
+
+// minSeedLen is the shortest seed accepted by the deterministic APIs
+const minSeedLen = 32
+
+var errShortSeed = newError(ErrBadArg, "seed must be at least 32 bytes")
+
+// newSeededPRNG returns a PRNG context initialized from seed
+func newSeededPRNG(seed []byte) (*PRNGContext, error) {
+	if len(seed) < minSeedLen {
+		return nil, errShortSeed
+	}
+	rng := &PRNGContext{}
+	rng.InitFromSeed(seed)
+	return rng, nil
+}
+
+// NewPRNGFromReader returns a PRNG context seeded with exactly seedLen
+// bytes read from r, such as crypto/rand.Reader or a hardware RNG
+// device. seedLen must be at least 32. The seed is wiped once the
+// context has absorbed it.
+func NewPRNGFromReader(r io.Reader, seedLen int) (*PRNGContext, error) {
+	if seedLen < minSeedLen {
+		return nil, newError(ErrBadArg, fmt.Sprintf("seed length %d is below the minimum of %d bytes", seedLen, minSeedLen))
+	}
+	seed := make([]byte, seedLen)
+	defer SecureZero(seed)
+	if _, err := io.ReadFull(r, seed); err != nil {
+		return nil, fmt.Errorf("failed to read %d seed bytes: %w", seedLen, err)
+	}
+	return newSeededPRNG(seed)
+}
+
+// GenerateKeyPairFromSeed deterministically generates a key pair for
+// logN from seed: the same seed always yields the same keys. The seed
+// must be at least 32 bytes. The keys are exactly as secret as the
+// seed, so it must come from a cryptographically secure source and be
+// protected like the private key itself.
+func GenerateKeyPairFromSeed(logN uint, seed []byte) (*KeyPair, error) {
+	if logN < 1 || logN > 10 {
+		return nil, errInvalidLogN
+	}
+	rng, err := newSeededPRNG(seed)
+	if err != nil {
+		return nil, err
+	}
+	return keygen(rng, logN)
+}
+
+// SignDeterministic signs message drawing all randomness (the nonce and
+// the sampler seed) from seed instead of the system RNG, so identical
+// inputs give byte-identical signatures in every format. For padded and
+// CT this is immediate since their length is fixed; compressed output
+// is stable too, because the encoded value depends only on the seeded
+// randomness. The seed must be at least 32 bytes and must never be
+// reused for a different message under the same key: two signatures
+// with the same nonce over different messages leak information about
+// the private key.
+func SignDeterministic(message, privateKey []byte, sigType int, seed []byte) ([]byte, error) {
+	rng, err := newSeededPRNG(seed)
+	if err != nil {
+		return nil, err
+	}
+	return signWithPRNG(rng, message, privateKey, sigType)
+}
+
+// SignWithSeed signs message with randomness derived from the seed, the
+// private key and the message, each prefixed with its length, in the
+// style of Ed25519's deterministic nonces. It is fully deterministic:
+// the system RNG is never consulted, so the signature is only as
+// unpredictable as seed is secret. The seed must be at least 32 bytes.
+// Identical inputs give identical signatures. Because the message is
+// absorbed, a seed may be reused across messages; what must never
+// happen is two different messages signed with the same randomness
+// under one key, as with SignDeterministic and a repeated seed, since
+// for lattice signatures that can reveal the private key.
+func SignWithSeed(message, privateKey []byte, sigType int, seed []byte) ([]byte, error) {
+	if len(seed) < minSeedLen {
+		return nil, errShortSeed
+	}
+	rng := &PRNGContext{}
+	rng.Init()
+	injectFramed(rng, seed, privateKey, message)
+	rng.Flip()
+	return signWithPRNG(rng, message, privateKey, sigType)
+}
+
+// ExpandedKeyFromSeed regenerates the private key for logN from seed and
+// returns its expanded form, so a signer can rebuild the expanded key on
+// demand instead of storing it. The result is identical for identical
+// inputs. The intermediate encoded private key is wiped before
+// returning.
+func ExpandedKeyFromSeed(logN uint, seed []byte) ([]byte, error) {
+	kp, err := GenerateKeyPairFromSeed(logN, seed)
+	if err != nil {
+		return nil, err
+	}
+	defer kp.Zero()
+	return expandPrivateKey(kp.PrivateKey)
+}
+
+// VerifyFromSeed regenerates the key pair for logN from seed and
+// verifies signature against its public key, for systems that store
+// seeds instead of public keys. Key generation dominates the cost. The
+// regenerated private key is wiped before returning.
+func VerifyFromSeed(signature, message, seed []byte, logN uint, sigType int) error {
+	kp, err := GenerateKeyPairFromSeed(logN, seed)
+	if err != nil {
+		return err
+	}
+	kp.Zero()
+	return Verify(signature, message, kp.PublicKey, SignatureType(sigType))
+}
+
+// TestKeys deterministically generates one key pair for every supported
+// degree (logN 1 to 10) from seed. Each degree draws from its own PRNG
+// derived from seed and logN, so a key does not depend on which other
+// degrees were generated. Intended for test fixtures only: anyone who
+// knows the seed knows the private keys.
+func TestKeys(seed []byte) (map[uint]*KeyPair, error) {
+	if len(seed) < minSeedLen {
+		return nil, errShortSeed
+	}
+
+	keys := make(map[uint]*KeyPair, 10)
+	for logN := uint(1); logN <= 10; logN++ {
+		rng, err := newSeededPRNG(shake256Framed(minSeedLen, seed, []byte{byte(logN)}))
+		if err != nil {
+			return nil, err
+		}
+		keyPair, err := keygen(rng, logN)
+		if err != nil {
+			return nil, fmt.Errorf("logN %d: %w", logN, err)
+		}
+		keys[logN] = keyPair
+	}
+	return keys, nil
+}
@@ -0,0 +1,266 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+func TestTestKeys(t *testing.T) {
+	seed := bytes.Repeat([]byte{0x42}, 32)
+	keys, err := TestKeys(seed)
+	if err != nil {
+		t.Fatalf("Failed to generate test keys: %v", err)
+	}
+	if len(keys) != 10 {
+		t.Fatalf("Expected 10 key pairs, got %d", len(keys))
+	}
+
+	again, err := TestKeys(seed)
+	if err != nil {
+		t.Fatalf("Failed to generate test keys: %v", err)
+	}
+	for logN := uint(1); logN <= 10; logN++ {
+		keyPair, ok := keys[logN]
+		if !ok {
+			t.Fatalf("Missing key pair for logN %d", logN)
+		}
+		if got, err := GetLogN(keyPair.PublicKey); err != nil || got != int(logN) {
+			t.Fatalf("Key for logN %d reports degree %d (%v)", logN, got, err)
+		}
+		if !bytes.Equal(keyPair.PublicKey, again[logN].PublicKey) ||
+			!bytes.Equal(keyPair.PrivateKey, again[logN].PrivateKey) {
+			t.Fatalf("Key pair for logN %d is not reproducible", logN)
+		}
+	}
+
+	other, err := TestKeys(bytes.Repeat([]byte{0x43}, 32))
+	if err != nil {
+		t.Fatalf("Failed to generate test keys: %v", err)
+	}
+	if bytes.Equal(keys[9].PublicKey, other[9].PublicKey) {
+		t.Fatal("Different seeds produced the same key")
+	}
+
+	if _, err := TestKeys(seed[:16]); !errors.Is(err, ErrBadArgument) {
+		t.Fatalf("Expected ErrBadArgument for short seed, got %v", err)
+	}
+}
+
+func TestExpandedKeyFromSeed(t *testing.T) {
+	seed := bytes.Repeat([]byte{0x5A}, 48)
+
+	expanded, err := ExpandedKeyFromSeed(9, seed)
+	if err != nil {
+		t.Fatalf("Failed to expand key from seed: %v", err)
+	}
+	again, err := ExpandedKeyFromSeed(9, seed)
+	if err != nil {
+		t.Fatalf("Failed to expand key from seed: %v", err)
+	}
+	if !bytes.Equal(expanded, again) {
+		t.Fatal("Expanded key is not deterministic")
+	}
+
+	// It must be the expansion of the key the same seed generates
+	kp, err := GenerateKeyPairFromSeed(9, seed)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	want, err := expandPrivateKey(kp.PrivateKey)
+	if err != nil {
+		t.Fatalf("Failed to expand private key: %v", err)
+	}
+	if !bytes.Equal(expanded, want) {
+		t.Fatal("Expanded key does not match the seeded private key")
+	}
+
+	other, err := ExpandedKeyFromSeed(9, bytes.Repeat([]byte{0x5B}, 48))
+	if err != nil {
+		t.Fatalf("Failed to expand key from seed: %v", err)
+	}
+	if bytes.Equal(expanded, other) {
+		t.Fatal("Different seeds produced the same expanded key")
+	}
+
+	if _, err := ExpandedKeyFromSeed(11, seed); err == nil {
+		t.Fatal("Expected error for invalid logN")
+	}
+	if _, err := ExpandedKeyFromSeed(9, seed[:8]); !errors.Is(err, ErrBadArgument) {
+		t.Fatalf("Expected ErrBadArgument for short seed, got %v", err)
+	}
+}
+
+func TestVerifyFromSeed(t *testing.T) {
+	seed := bytes.Repeat([]byte{0x31}, 32)
+	kp, err := GenerateKeyPairFromSeed(9, seed)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	message := []byte("seed-verified")
+	signature, err := Sign(message, kp.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	if err := VerifyFromSeed(signature, message, seed, 9, SigCompressed); err != nil {
+		t.Fatalf("Failed to verify from seed: %v", err)
+	}
+	if err := VerifyFromSeed(signature, message, bytes.Repeat([]byte{0x32}, 32), 9, SigCompressed); err == nil {
+		t.Fatal("Verification succeeded with another seed")
+	}
+	if err := VerifyFromSeed(signature, message, seed, 10, SigCompressed); err == nil {
+		t.Fatal("Verification succeeded with another degree")
+	}
+	if err := VerifyFromSeed(signature, []byte("other"), seed, 9, SigCompressed); err == nil {
+		t.Fatal("Verification succeeded for modified message")
+	}
+}
+
+func TestGenerateKeyPairFromSeed(t *testing.T) {
+	seed := bytes.Repeat([]byte{0x11}, 32)
+
+	a, err := GenerateKeyPairFromSeed(9, seed)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	b, err := GenerateKeyPairFromSeed(9, seed)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	if !bytes.Equal(a.PublicKey, b.PublicKey) || !bytes.Equal(a.PrivateKey, b.PrivateKey) {
+		t.Fatal("Same seed produced different keys")
+	}
+
+	seen := map[string]bool{string(a.PublicKey): true}
+	for i := 0; i < 4; i++ {
+		other := append([]byte{}, seed...)
+		other[i] ^= 0x80
+		kp, err := GenerateKeyPairFromSeed(9, other)
+		if err != nil {
+			t.Fatalf("Failed to generate key pair: %v", err)
+		}
+		if seen[string(kp.PublicKey)] {
+			t.Fatal("Different seeds produced the same key")
+		}
+		seen[string(kp.PublicKey)] = true
+	}
+
+	if _, err := GenerateKeyPairFromSeed(9, seed[:31]); !errors.Is(err, ErrBadArgument) {
+		t.Fatalf("Expected ErrBadArgument for a seed shorter than 32 bytes, got %v", err)
+	}
+	if _, err := GenerateKeyPairFromSeed(0, seed); err == nil {
+		t.Fatal("Expected error for invalid logN")
+	}
+}
+
+func TestSignDeterministic(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	seed := bytes.Repeat([]byte{0x77}, 32)
+	message := []byte("reproducible")
+
+	for _, sigType := range []int{SigCompressed, SigPadded, SigCT} {
+		a, err := SignDeterministic(message, keyPair.PrivateKey, sigType, seed)
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		b, err := SignDeterministic(message, keyPair.PrivateKey, sigType, seed)
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		if !bytes.Equal(a, b) {
+			t.Fatalf("Type %d: same seed produced different signatures", sigType)
+		}
+		if err := Verify(a, message, keyPair.PublicKey, SignatureType(sigType)); err != nil {
+			t.Fatalf("Type %d: deterministic signature failed verification: %v", sigType, err)
+		}
+
+		c, err := SignDeterministic(message, keyPair.PrivateKey, sigType, bytes.Repeat([]byte{0x78}, 32))
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		if bytes.Equal(a, c) {
+			t.Fatalf("Type %d: different seeds produced the same signature", sigType)
+		}
+	}
+
+	if _, err := SignDeterministic(message, keyPair.PrivateKey, SigCompressed, seed[:16]); !errors.Is(err, ErrBadArgument) {
+		t.Fatalf("Expected ErrBadArgument for short seed, got %v", err)
+	}
+}
+
+func TestNewPRNGFromReader(t *testing.T) {
+	seed := bytes.Repeat([]byte{0x17}, 48)
+	rng, err := NewPRNGFromReader(bytes.NewReader(seed), len(seed))
+	if err != nil {
+		t.Fatalf("Failed to seed PRNG from reader: %v", err)
+	}
+	var want PRNGContext
+	want.InitFromSeed(seed)
+	got, expected := make([]byte, 64), make([]byte, 64)
+	rng.Extract(got)
+	want.Extract(expected)
+	if !bytes.Equal(got, expected) {
+		t.Fatal("Reader-seeded PRNG differs from InitFromSeed on the same bytes")
+	}
+
+	if _, err := NewPRNGFromReader(rand.Reader, 32); err != nil {
+		t.Fatalf("Failed to seed PRNG from crypto/rand: %v", err)
+	}
+	if _, err := NewPRNGFromReader(rand.Reader, 16); !errors.Is(err, ErrBadArgument) {
+		t.Fatalf("Expected ErrBadArgument for a short seed length, got %v", err)
+	}
+	if _, err := NewPRNGFromReader(bytes.NewReader(seed[:20]), 32); err == nil {
+		t.Fatal("Expected error for a reader that runs out of data")
+	}
+}
+
+func TestSignWithSeed(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	seed := bytes.Repeat([]byte{0x5A}, 32)
+	message := []byte("seeded signing")
+
+	for _, sigType := range []int{SigCompressed, SigPadded, SigCT} {
+		first, err := SignWithSeed(message, keyPair.PrivateKey, sigType, seed)
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		second, err := SignWithSeed(message, keyPair.PrivateKey, sigType, seed)
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		if !bytes.Equal(first, second) {
+			t.Fatalf("type %d: same inputs gave different signatures", sigType)
+		}
+		if err := Verify(first, message, keyPair.PublicKey, SignatureType(sigType)); err != nil {
+			t.Fatalf("Signature verification failed: %v", err)
+		}
+	}
+
+	// The message is absorbed, so different messages get different nonces
+	a, err := SignWithSeed([]byte("one"), keyPair.PrivateKey, SigCT, seed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	b, err := SignWithSeed([]byte("two"), keyPair.PrivateKey, SigCT, seed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	if bytes.Equal(a[1:1+nonceSize], b[1:1+nonceSize]) {
+		t.Fatal("Different messages share a nonce")
+	}
+
+	if _, err := SignWithSeed(message, keyPair.PrivateKey, SigCompressed, seed[:31]); !errors.Is(err, ErrBadArgument) {
+		t.Fatalf("Expected ErrBadArgument for a short seed, got %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+package falcon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Signature log record tags
+const (
+	logTagEntry = 0x01
+	logTagSeal  = 0x02
+)
+
+// logHashSize is the length of message hashes and of the seal digest
+const logHashSize = 64
+
+// logSealContext is the SignWithContext context string of seal
+// signatures, keeping them apart from entry signatures
+var logSealContext = []byte("falcon-go signature log seal")
+
+// SignatureLog appends signed records to an io.Writer and finishes with
+// a tamper-evident seal.
+//
+// Each entry is framed as
+//
+//	0x01 || SHAKE256(message) (64 bytes) || uint16 BE signature length || signature
+//
+// where the signature is computed over the 64-byte message hash. Seal
+// writes a final record
+//
+//	0x02 || digest (64 bytes) || uint16 BE signature length || signature
+//
+// where digest is a SHAKE256 digest of every entry byte written before
+// it, and the signature covers the digest under a fixed context string
+// (see SignWithContext). Dropping, reordering or replacing entries
+// changes the digest, and forging a matching seal requires the sealing
+// key. A SignatureLog is not safe for concurrent use.
+type SignatureLog struct {
+	w      io.Writer
+	digest *PRNGContext
+	sealed bool
+}
+
+// NewSignatureLog creates a log writing to w
+func NewSignatureLog(w io.Writer) *SignatureLog {
+	digest := &PRNGContext{}
+	digest.Init()
+	return &SignatureLog{w: w, digest: digest}
+}
+
+// Append hashes message, signs the hash and writes the entry
+func (l *SignatureLog) Append(message, privateKey []byte, sigType int) error {
+	if l.sealed {
+		return newError(ErrBadArg, "signature log already sealed")
+	}
+
+	msgHash := shake256(logHashSize, message)
+	sig, err := Sign(msgHash, privateKey, SignatureType(sigType))
+	if err != nil {
+		return err
+	}
+
+	entry := make([]byte, 0, 1+logHashSize+2+len(sig))
+	entry = append(entry, logTagEntry)
+	entry = append(entry, msgHash...)
+	entry = binary.BigEndian.AppendUint16(entry, uint16(len(sig)))
+	entry = append(entry, sig...)
+
+	if _, err := l.w.Write(entry); err != nil {
+		return err
+	}
+	l.digest.Inject(entry)
+	return nil
+}
+
+// Seal signs the digest of all entries with privateKey, writes the seal
+// record and returns the digest. No entries can be appended afterwards.
+func (l *SignatureLog) Seal(privateKey []byte, sigType int) ([]byte, error) {
+	if l.sealed {
+		return nil, newError(ErrBadArg, "signature log already sealed")
+	}
+
+	digest := *l.digest
+	digest.Flip()
+	seal := make([]byte, logHashSize)
+	digest.Extract(seal)
+
+	sig, err := SignWithContext(seal, logSealContext, privateKey, sigType)
+	if err != nil {
+		return nil, err
+	}
+	l.sealed = true
+
+	record := make([]byte, 0, 1+logHashSize+2+len(sig))
+	record = append(record, logTagSeal)
+	record = append(record, seal...)
+	record = binary.BigEndian.AppendUint16(record, uint16(len(sig)))
+	record = append(record, sig...)
+	if _, err := l.w.Write(record); err != nil {
+		return nil, err
+	}
+	return seal, nil
+}
+
+// VerifySignatureLog reads a sealed log from r, verifies every entry
+// with the public key returned by pubResolver for its index, and checks
+// the seal against the digest of the entries and with the key
+// pubResolver returns for the seal's index, which is the number of
+// entries. The log must end with the seal record.
+func VerifySignatureLog(r io.Reader, pubResolver func(index int) []byte, sigType int) error {
+	digest := &PRNGContext{}
+	digest.Init()
+
+	header := make([]byte, 1+logHashSize)
+	for index := 0; ; index++ {
+		if _, err := io.ReadFull(r, header[:1]); err != nil {
+			if err == io.EOF {
+				return fmt.Errorf("%w: signature log is not sealed", ErrBadFormat)
+			}
+			return err
+		}
+
+		switch header[0] {
+		case logTagEntry:
+			var lenBuf [2]byte
+			if _, err := io.ReadFull(r, header[1:]); err != nil {
+				return fmt.Errorf("entry %d: %w", index, err)
+			}
+			if _, err := io.ReadFull(r, lenBuf[:]); err != nil {
+				return fmt.Errorf("entry %d: %w", index, err)
+			}
+			sig := make([]byte, binary.BigEndian.Uint16(lenBuf[:]))
+			if _, err := io.ReadFull(r, sig); err != nil {
+				return fmt.Errorf("entry %d: %w", index, err)
+			}
+
+			if err := Verify(sig, header[1:], pubResolver(index), SignatureType(sigType)); err != nil {
+				return fmt.Errorf("entry %d: %w", index, err)
+			}
+
+			digest.Inject(header)
+			digest.Inject(lenBuf[:])
+			digest.Inject(sig)
+
+		case logTagSeal:
+			var lenBuf [2]byte
+			if _, err := io.ReadFull(r, header[1:]); err != nil {
+				return fmt.Errorf("seal: %w", err)
+			}
+			if _, err := io.ReadFull(r, lenBuf[:]); err != nil {
+				return fmt.Errorf("seal: %w", err)
+			}
+			sig := make([]byte, binary.BigEndian.Uint16(lenBuf[:]))
+			if _, err := io.ReadFull(r, sig); err != nil {
+				return fmt.Errorf("seal: %w", err)
+			}
+
+			digest.Flip()
+			expected := make([]byte, logHashSize)
+			digest.Extract(expected)
+			if !bytes.Equal(header[1:], expected) {
+				return fmt.Errorf("%w: signature log seal mismatch", ErrBadFormat)
+			}
+			if err := VerifyWithContext(sig, expected, logSealContext, pubResolver(index), sigType); err != nil {
+				return fmt.Errorf("seal: %w", err)
+			}
+			if n, _ := r.Read(make([]byte, 1)); n != 0 {
+				return fmt.Errorf("%w: trailing data after signature log seal", ErrBadFormat)
+			}
+			return nil
+
+		default:
+			return fmt.Errorf("entry %d: unknown record tag 0x%02x", index, header[0])
+		}
+	}
+}
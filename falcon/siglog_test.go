@@ -0,0 +1,115 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSignatureLog(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	resolve := func(int) []byte { return keyPair.PublicKey }
+
+	writeLog := func(messages []string) ([]byte, []int) {
+		var buf bytes.Buffer
+		log := NewSignatureLog(&buf)
+		var offsets []int
+		for _, m := range messages {
+			offsets = append(offsets, buf.Len())
+			if err := log.Append([]byte(m), keyPair.PrivateKey, SigCompressed); err != nil {
+				t.Fatalf("Failed to append entry: %v", err)
+			}
+		}
+		// The last offset is that of the seal record
+		offsets = append(offsets, buf.Len())
+		if _, err := log.Seal(keyPair.PrivateKey, SigCompressed); err != nil {
+			t.Fatalf("Failed to seal log: %v", err)
+		}
+		if _, err := log.Seal(keyPair.PrivateKey, SigCompressed); err == nil {
+			t.Fatal("Expected error sealing a log twice")
+		}
+		if err := log.Append([]byte("late"), keyPair.PrivateKey, SigCompressed); err == nil {
+			t.Fatal("Expected error appending to a sealed log")
+		}
+		return buf.Bytes(), offsets
+	}
+
+	messages := []string{"first record", "second record", "third record"}
+	data, offsets := writeLog(messages)
+
+	if err := VerifySignatureLog(bytes.NewReader(data), resolve, SigCompressed); err != nil {
+		t.Fatalf("Failed to verify signature log: %v", err)
+	}
+
+	// Corrupt the signature of the middle entry
+	tampered := append([]byte{}, data...)
+	tampered[offsets[1]+1+logHashSize+2+50] ^= 0x01
+	err = VerifySignatureLog(bytes.NewReader(tampered), resolve, SigCompressed)
+	if err == nil || !strings.Contains(err.Error(), "entry 1") {
+		t.Fatalf("Expected failure at entry 1, got %v", err)
+	}
+
+	// Swap in a validly signed middle entry from another log: every
+	// signature checks out, but the seal no longer matches
+	other, otherOffsets := writeLog([]string{"first record", "forged record", "third record"})
+	spliced := append([]byte{}, data[:offsets[1]]...)
+	spliced = append(spliced, other[otherOffsets[1]:otherOffsets[2]]...)
+	spliced = append(spliced, data[offsets[2]:]...)
+	err = VerifySignatureLog(bytes.NewReader(spliced), resolve, SigCompressed)
+	if err == nil || !strings.Contains(err.Error(), "seal") {
+		t.Fatalf("Expected seal mismatch, got %v", err)
+	}
+
+	// Dropping an entry and recomputing the digest is not enough: the
+	// seal signature no longer matches
+	entries := append(append([]byte{}, data[:offsets[1]]...), data[offsets[2]:offsets[3]]...)
+	sealSig := data[offsets[3]+1+logHashSize:]
+	forged := append(entries, logTagSeal)
+	forged = append(forged, shake256(logHashSize, entries)...)
+	forged = append(forged, sealSig...)
+	err = VerifySignatureLog(bytes.NewReader(forged), resolve, SigCompressed)
+	if err == nil || !strings.Contains(err.Error(), "seal") || !errors.Is(err, ErrBadSignature) {
+		t.Fatalf("Expected seal signature failure for a dropped entry, got %v", err)
+	}
+
+	// Resealing with another key fails against the expected sealing key
+	otherKey, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	var resealed bytes.Buffer
+	resealed.Write(entries)
+	forger := NewSignatureLog(&resealed)
+	forger.digest.Inject(entries)
+	if _, err := forger.Seal(otherKey.PrivateKey, SigCompressed); err != nil {
+		t.Fatalf("Failed to seal log: %v", err)
+	}
+	err = VerifySignatureLog(bytes.NewReader(resealed.Bytes()), resolve, SigCompressed)
+	if err == nil || !strings.Contains(err.Error(), "seal") {
+		t.Fatalf("Expected seal failure for a log resealed with another key, got %v", err)
+	}
+
+	// A log without its seal is rejected
+	err = VerifySignatureLog(bytes.NewReader(data[:offsets[2]]), resolve, SigCompressed)
+	if err == nil {
+		t.Fatal("Expected error for unsealed log")
+	}
+
+	// Wrong key for one index
+	err = VerifySignatureLog(bytes.NewReader(data), func(i int) []byte {
+		if i == 2 {
+			return otherKey.PublicKey
+		}
+		return keyPair.PublicKey
+	}, SigCompressed)
+	if err == nil || !strings.Contains(err.Error(), fmt.Sprintf("entry %d", 2)) {
+		t.Fatalf("Expected failure at entry 2, got %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package falcon
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// sigStringPrefix is how many leading signature bytes String shows
+const sigStringPrefix = 8
+
+// Signature is an encoded signature whose header and length have been
+// checked by ParseSignature. Holding a *Signature rather than a []byte
+// records that the parse has happened; verification is still needed to
+// know whether it is valid.
+type Signature struct {
+	raw     []byte
+	sigType int
+	logN    int
+}
+
+// ParseSignature checks the header and length of raw, detects its type
+// as DetectSigType does, and returns it as a Signature. raw is copied.
+// Malformed signatures yield errors matching ErrBadFormat.
+func ParseSignature(raw []byte) (*Signature, error) {
+	sigType, err := DetectSigType(raw)
+	if err != nil {
+		return nil, fmt.Errorf("malformed signature: %w", err)
+	}
+	return &Signature{
+		raw:     append([]byte{}, raw...),
+		sigType: sigType,
+		logN:    int(raw[0] & 0x0F),
+	}, nil
+}
+
+// Bytes returns the encoded signature. The caller must not modify it.
+func (s *Signature) Bytes() []byte {
+	return s.raw
+}
+
+// Type returns the encoding: SigCompressed, SigPadded or SigCT
+func (s *Signature) Type() int {
+	return s.sigType
+}
+
+// LogN returns the degree (log2) read from the header
+func (s *Signature) LogN() int {
+	return s.logN
+}
+
+// Size returns the length of the encoded signature in bytes
+func (s *Signature) Size() int {
+	return len(s.raw)
+}
+
+// Verify checks the signature over message against publicKey. A public
+// key of a different degree is rejected before calling into C.
+func (s *Signature) Verify(message []byte, publicKey PublicKey) error {
+	logN, err := checkPublicKey(publicKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	if int(logN) != s.logN {
+		return fmt.Errorf("%w: signature logN %d, public key logN %d", ErrUnsupportedDegree, s.logN, logN)
+	}
+	return verify(s.raw, message, publicKey, s.sigType, logN)
+}
+
+// String describes the signature for debugging: its type, degree, size
+// and the first bytes in hex
+func (s *Signature) String() string {
+	prefix := s.raw
+	if len(prefix) > sigStringPrefix {
+		prefix = prefix[:sigStringPrefix]
+	}
+	return fmt.Sprintf("Signature{type: %s, logN: %d, size: %d, data: %s...}",
+		SignatureType(s.sigType), s.logN, len(s.raw), hex.EncodeToString(prefix))
+}
+
+// signatureJSONVersion is the version of the Signature JSON layout
+const signatureJSONVersion = "1"
+
+// signatureJSON is the JSON form of a Signature
+type signatureJSON struct {
+	Version string `json:"version"`
+	Type    int    `json:"type"`
+	LogN    int    `json:"logN"`
+	Data    []byte `json:"data"`
+}
+
+// MarshalJSON encodes the layout version, the signature type as its
+// integer constant, the degree, and the encoded signature in base64. It
+// has a value receiver so that Signature values, such as struct fields
+// and slice elements, are encoded too.
+func (s Signature) MarshalJSON() ([]byte, error) {
+	return json.Marshal(signatureJSON{
+		Version: signatureJSONVersion,
+		Type:    s.sigType,
+		LogN:    s.logN,
+		Data:    s.raw,
+	})
+}
+
+// UnmarshalJSON decodes the output of MarshalJSON. All four fields are
+// required, the version must be "1", and the data must be a well-formed
+// signature of the stated type and degree; unknown fields are ignored.
+func (s *Signature) UnmarshalJSON(data []byte) error {
+	var v signatureJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch {
+	case v.Version == "":
+		return errors.New("signature JSON: missing version")
+	case v.Version != signatureJSONVersion:
+		return fmt.Errorf("unsupported signature JSON version %q", v.Version)
+	case v.Type == 0:
+		return errors.New("signature JSON: missing type")
+	case v.LogN == 0:
+		return errors.New("signature JSON: missing logN")
+	case len(v.Data) == 0:
+		return errors.New("signature JSON: missing data")
+	}
+
+	logN, err := checkSignature(v.Data, v.Type)
+	if err != nil {
+		return fmt.Errorf("malformed signature: %w", err)
+	}
+	if int(logN) != v.LogN {
+		return fmt.Errorf("%w: logN %d does not match signature of degree %d", ErrBadFormat, v.LogN, logN)
+	}
+	*s = Signature{raw: v.Data, sigType: v.Type, logN: v.LogN}
+	return nil
+}
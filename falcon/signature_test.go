@@ -0,0 +1,136 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseSignature(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	message := []byte("typed signature")
+
+	for _, sigType := range []int{SigCompressed, SigPadded, SigCT} {
+		raw, err := Sign(message, keyPair.PrivateKey, SignatureType(sigType))
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		sig, err := ParseSignature(raw)
+		if err != nil {
+			t.Fatalf("Failed to parse signature: %v", err)
+		}
+		if sig.Type() != sigType || sig.LogN() != 9 || sig.Size() != len(raw) {
+			t.Fatalf("Parsed %v from a type %d signature of %d bytes", sig, sigType, len(raw))
+		}
+		if !bytes.Equal(sig.Bytes(), raw) {
+			t.Fatal("Bytes differs from the parsed input")
+		}
+		raw[len(raw)-1] ^= 1
+		if !bytes.Equal(sig.Bytes()[:len(raw)-1], raw[:len(raw)-1]) || sig.Bytes()[len(raw)-1] == raw[len(raw)-1] {
+			t.Fatal("Signature shares memory with its input")
+		}
+
+		if err := sig.Verify(message, keyPair.PublicKey); err != nil {
+			t.Fatalf("Signature verification failed: %v", err)
+		}
+		if err := sig.Verify([]byte("other"), keyPair.PublicKey); !errors.Is(err, ErrBadSignature) {
+			t.Fatalf("Expected ErrBadSignature, got %v", err)
+		}
+
+		s := sig.String()
+		if !strings.Contains(s, SignatureType(sigType).String()) || !strings.Contains(s, "logN: 9") {
+			t.Fatalf("String() = %q lacks type or degree", s)
+		}
+	}
+
+	other, err := GenerateKeyPair(10)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	raw, err := Sign(message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	sig, err := ParseSignature(raw)
+	if err != nil {
+		t.Fatalf("Failed to parse signature: %v", err)
+	}
+	if err := sig.Verify(message, other.PublicKey); !errors.Is(err, ErrUnsupportedDegree) {
+		t.Fatalf("Expected ErrUnsupportedDegree for a Falcon-1024 key, got %v", err)
+	}
+
+	for name, bad := range map[string][]byte{
+		"Empty":     nil,
+		"PublicKey": keyPair.PublicKey,
+		"NonceOnly": raw[:1+nonceSize],
+	} {
+		if _, err := ParseSignature(bad); !errors.Is(err, ErrBadFormat) {
+			t.Errorf("%s: expected ErrBadFormat, got %v", name, err)
+		}
+	}
+}
+
+func TestSignatureJSON(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	message := []byte("json signature")
+	raw, err := Sign(message, keyPair.PrivateKey, SigCT)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	sig, err := ParseSignature(raw)
+	if err != nil {
+		t.Fatalf("Failed to parse signature: %v", err)
+	}
+
+	data, err := json.Marshal(*sig)
+	if err != nil {
+		t.Fatalf("Failed to marshal signature: %v", err)
+	}
+	var decoded Signature
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal signature: %v", err)
+	}
+	if decoded.Type() != SigCT || decoded.LogN() != 9 || !bytes.Equal(decoded.Bytes(), raw) {
+		t.Fatalf("Signature changed in JSON round trip: %v", &decoded)
+	}
+	if err := decoded.Verify(message, keyPair.PublicKey); err != nil {
+		t.Fatalf("Signature verification failed: %v", err)
+	}
+
+	wrapped, err := json.Marshal(struct{ Sigs []Signature }{[]Signature{*sig}})
+	if err != nil {
+		t.Fatalf("Failed to marshal signature slice: %v", err)
+	}
+	if !bytes.Contains(wrapped, data) {
+		t.Fatalf("Signature value not encoded in slice: %s", wrapped)
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(raw)
+	if err := json.Unmarshal([]byte(`{"version":"1","type":3,"logN":9,"data":"`+encoded+`","note":"x"}`), &decoded); err != nil {
+		t.Fatalf("Unknown field rejected: %v", err)
+	}
+	for name, input := range map[string]string{
+		"MissingVersion": `{"type":3,"logN":9,"data":"` + encoded + `"}`,
+		"UnknownVersion": `{"version":"2","type":3,"logN":9,"data":"` + encoded + `"}`,
+		"MissingType":    `{"version":"1","logN":9,"data":"` + encoded + `"}`,
+		"MissingLogN":    `{"version":"1","type":3,"data":"` + encoded + `"}`,
+		"MissingData":    `{"version":"1","type":3,"logN":9}`,
+		"MismatchedLogN": `{"version":"1","type":3,"logN":10,"data":"` + encoded + `"}`,
+		"WrongType":      `{"version":"1","type":1,"logN":9,"data":"` + encoded + `"}`,
+	} {
+		if err := json.Unmarshal([]byte(input), &decoded); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package falcon
+
+import (
+	"fmt"
+	"sync"
+)
+
+// signerKey is the key material a Signer swaps as a unit
+type signerKey struct {
+	privateKey []byte
+	publicKey  []byte
+}
+
+// Signer signs messages with a private key that can be replaced while
+// signatures are being produced. It is safe for concurrent use.
+type Signer struct {
+	mu      sync.RWMutex
+	key     *signerKey
+	sigType int
+}
+
+// newSignerKey validates privateKey and derives its public key. The
+// private key is copied so later changes by the caller have no effect.
+func newSignerKey(privateKey []byte) (*signerKey, error) {
+	publicKey, err := derivePublicKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return &signerKey{
+		privateKey: append([]byte{}, privateKey...),
+		publicKey:  publicKey,
+	}, nil
+}
+
+// NewSigner creates a Signer producing signatures of the given type
+func NewSigner(privateKey []byte, sigType int) (*Signer, error) {
+	if _, err := sigBufferSize(1, sigType); err != nil {
+		return nil, err
+	}
+	key, err := newSignerKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{key: key, sigType: sigType}, nil
+}
+
+// current returns the key in use
+func (s *Signer) current() *signerKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.key
+}
+
+// Sign signs message with the current key. A concurrent Rotate makes the
+// call use either the old or the new key, never a mix of both.
+func (s *Signer) Sign(message []byte) ([]byte, error) {
+	return Sign(message, s.current().privateKey, SignatureType(s.sigType))
+}
+
+// PublicKey returns the public key matching the current private key
+func (s *Signer) PublicKey() []byte {
+	return append([]byte{}, s.current().publicKey...)
+}
+
+// Rotate atomically replaces the signing key. The new key is validated
+// first; on error the current key stays in place.
+func (s *Signer) Rotate(newPrivateKey []byte) error {
+	key, err := newSignerKey(newPrivateKey)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.key = key
+	return nil
+}
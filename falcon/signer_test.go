@@ -0,0 +1,84 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func TestSignerRotate(t *testing.T) {
+	oldKey, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	newKey, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	signer, err := NewSigner(oldKey.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+	if !bytes.Equal(signer.PublicKey(), oldKey.PublicKey) {
+		t.Fatal("Signer public key does not match the private key")
+	}
+
+	message := []byte("rotating")
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				signature, err := signer.Sign(message)
+				if err != nil {
+					t.Errorf("Failed to sign message: %v", err)
+					return
+				}
+				if Verify(signature, message, oldKey.PublicKey, SigCompressed) != nil &&
+					Verify(signature, message, newKey.PublicKey, SigCompressed) != nil {
+					t.Error("Signature verifies under neither the old nor the new key")
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			key := newKey
+			if i%2 == 1 {
+				key = oldKey
+			}
+			if err := signer.Rotate(key.PrivateKey); err != nil {
+				t.Errorf("Failed to rotate key: %v", err)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+
+	if err := signer.Rotate(newKey.PrivateKey); err != nil {
+		t.Fatalf("Failed to rotate key: %v", err)
+	}
+	signature, err := signer.Sign(message)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	if err := Verify(signature, message, newKey.PublicKey, SigCompressed); err != nil {
+		t.Fatalf("Signature after rotation failed verification: %v", err)
+	}
+
+	// A bad key is rejected and the current key stays in use
+	if err := signer.Rotate(newKey.PublicKey); err == nil {
+		t.Fatal("Expected error rotating to a public key")
+	}
+	if !bytes.Equal(signer.PublicKey(), newKey.PublicKey) {
+		t.Fatal("Failed rotation replaced the key")
+	}
+}
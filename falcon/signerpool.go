@@ -0,0 +1,96 @@
+package falcon
+
+import (
+	"fmt"
+	"sync"
+)
+
+// signerSlot is the per-call state reused by a SignerPool
+type signerSlot struct {
+	rng     *PRNGContext // seeded once, then advanced by each signature
+	signTmp []byte
+	verTmp  []byte
+}
+
+// SignerPool signs and verifies with keys of one degree while reusing
+// scratch buffers and seeded PRNGs across calls, which removes the
+// per-call allocations of Sign and Verify apart from the returned
+// signature. Slots are kept in a sync.Pool, so the pool grows with
+// concurrency and shrinks again under GC. It is safe for concurrent use.
+type SignerPool struct {
+	logN  uint
+	err   error
+	slots sync.Pool
+}
+
+// NewSignerPool returns a pool for keys of degree logN. An invalid logN
+// is reported by every call to Sign and Verify.
+func NewSignerPool(logN uint) *SignerPool {
+	p := &SignerPool{logN: logN}
+	if logN < 1 || logN > 10 {
+		p.err = errInvalidLogN
+	}
+	return p
+}
+
+// get returns a slot, creating and seeding a new one if none is free
+func (p *SignerPool) get() (*signerSlot, error) {
+	if slot, ok := p.slots.Get().(*signerSlot); ok {
+		return slot, nil
+	}
+	rng, err := newSystemPRNG()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize RNG: %w", err)
+	}
+	return &signerSlot{
+		rng:     rng,
+		signTmp: make([]byte, tmpSizeSignDyn(p.logN)),
+		verTmp:  make([]byte, tmpSizeVerify(p.logN)),
+	}, nil
+}
+
+// Sign signs message with privateKey, which must be of the pool's degree
+func (p *SignerPool) Sign(message, privateKey []byte, sigType int) ([]byte, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	logN, err := checkPrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	if logN != p.logN {
+		return nil, fmt.Errorf("%w: Falcon-%d private key used with a Falcon-%d pool", ErrUnsupportedDegree, 1<<logN, 1<<p.logN)
+	}
+
+	slot, err := p.get()
+	if err != nil {
+		return nil, err
+	}
+	defer p.slots.Put(slot)
+	return signWithTmp(slot.rng, message, privateKey, sigType, logN, slot.signTmp)
+}
+
+// Verify verifies signature with publicKey, which must be of the pool's
+// degree
+func (p *SignerPool) Verify(signature, message, publicKey []byte, sigType int) error {
+	if p.err != nil {
+		return p.err
+	}
+	logN, err := checkPublicKey(publicKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	if logN != p.logN {
+		return fmt.Errorf("%w: Falcon-%d public key used with a Falcon-%d pool", ErrUnsupportedDegree, 1<<logN, 1<<p.logN)
+	}
+	if _, err := checkSignature(signature, sigType); err != nil {
+		return fmt.Errorf("malformed signature: %w", err)
+	}
+
+	slot, err := p.get()
+	if err != nil {
+		return err
+	}
+	defer p.slots.Put(slot)
+	return verifyWithTmp(signature, message, publicKey, sigType, slot.verTmp)
+}
@@ -0,0 +1,69 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestSignerPool(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	other, err := GenerateKeyPair(10)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	pool := NewSignerPool(9)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 16)
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 5; i++ {
+				message := []byte(fmt.Sprintf("pooled %d/%d", g, i))
+				signature, err := pool.Sign(message, keyPair.PrivateKey, SigCompressed)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if err := pool.Verify(signature, message, keyPair.PublicKey, SigCompressed); err != nil {
+					errs <- err
+					return
+				}
+				if err := Verify(signature, message, keyPair.PublicKey, SigCompressed); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("Pooled operation failed: %v", err)
+	}
+
+	signature, err := pool.Sign([]byte("m"), keyPair.PrivateKey, SigPadded)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	if err := pool.Verify(signature, []byte("x"), keyPair.PublicKey, SigPadded); !errors.Is(err, ErrBadSignature) {
+		t.Fatalf("Expected ErrBadSignature, got %v", err)
+	}
+	if _, err := pool.Sign([]byte("m"), other.PrivateKey, SigCompressed); !errors.Is(err, ErrUnsupportedDegree) {
+		t.Fatalf("Expected ErrUnsupportedDegree for a 1024 key, got %v", err)
+	}
+	if err := pool.Verify(signature, []byte("m"), other.PublicKey, SigPadded); !errors.Is(err, ErrUnsupportedDegree) {
+		t.Fatalf("Expected ErrUnsupportedDegree for a 1024 key, got %v", err)
+	}
+	if _, err := NewSignerPool(11).Sign([]byte("m"), keyPair.PrivateKey, SigCompressed); !errors.Is(err, ErrBadArgument) {
+		t.Fatalf("Expected ErrBadArgument for an invalid pool degree, got %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package falcon
+
+import "fmt"
+
+// SignatureType names a signature encoding. Sign and Verify take a
+// SignatureType, so passing an unrelated integer fails to compile; the
+// rest of the API still takes a plain int, so convert with int(t) there.
+// The untyped SigCompressed, SigPadded and SigCT constants work with
+// either form.
+type SignatureType int
+
+// Typed signature encodings, equal to the untyped Sig* constants
+const (
+	SigTypeCompressed SignatureType = SigCompressed
+	SigTypePadded     SignatureType = SigPadded
+	SigTypeCT         SignatureType = SigCT
+)
+
+// String returns the name of the encoding for logging. 0, which Verify
+// accepts as "detect from the header", is reported as "auto".
+func (t SignatureType) String() string {
+	switch t {
+	case 0:
+		return "auto"
+	case SigTypeCompressed:
+		return "compressed"
+	case SigTypePadded:
+		return "padded"
+	case SigTypeCT:
+		return "constant-time"
+	default:
+		return fmt.Sprintf("SignatureType(%d)", int(t))
+	}
+}
+
+// Valid reports whether t is one of the three encodings
+func (t SignatureType) Valid() bool {
+	return t == SigTypeCompressed || t == SigTypePadded || t == SigTypeCT
+}
@@ -0,0 +1,54 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSignatureType(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	tests := []struct {
+		sigType SignatureType
+		name    string
+	}{
+		{SigTypeCompressed, "compressed"},
+		{SigTypePadded, "padded"},
+		{SigTypeCT, "constant-time"},
+	}
+	message := []byte("typed")
+	for _, tt := range tests {
+		if got := fmt.Sprint(tt.sigType); got != tt.name {
+			t.Fatalf("Expected %q, got %q", tt.name, got)
+		}
+		if !tt.sigType.Valid() {
+			t.Fatalf("%v reported invalid", tt.sigType)
+		}
+
+		signature, err := Sign(message, keyPair.PrivateKey, tt.sigType)
+		if err != nil {
+			t.Fatalf("Failed to sign message as %v: %v", tt.sigType, err)
+		}
+		if err := Verify(signature, message, keyPair.PublicKey, tt.sigType); err != nil {
+			t.Fatalf("Failed to verify %v signature: %v", tt.sigType, err)
+		}
+	}
+
+	// The untyped constants convert to the named type
+	var st SignatureType = SigCT
+	if st != SigTypeCT {
+		t.Fatal("SigCT does not match SigTypeCT")
+	}
+
+	if SignatureType(0).String() != "auto" {
+		t.Fatalf("Unexpected name for 0: %v", SignatureType(0))
+	}
+	if SignatureType(9).Valid() || SignatureType(9).String() != "SignatureType(9)" {
+		t.Fatalf("Unexpected handling of invalid type: %v", SignatureType(9))
+	}
+}
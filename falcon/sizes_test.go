@@ -0,0 +1,145 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+func TestSizes(t *testing.T) {
+	// Falcon-512 and Falcon-1024 sizes from the specification
+	want := map[uint]SizeInfo{
+		9:  {PrivateKey: 1281, PublicKey: 897, SigCompressedMax: 752, SigPadded: 666, SigCT: 809},
+		10: {PrivateKey: 2305, PublicKey: 1793, SigCompressedMax: 1462, SigPadded: 1280, SigCT: 1577},
+	}
+	for logN, w := range want {
+		got, err := Sizes(logN)
+		if err != nil {
+			t.Fatalf("Sizes(%d) failed: %v", logN, err)
+		}
+		if got.PrivateKey != w.PrivateKey || got.PublicKey != w.PublicKey ||
+			got.SigCompressedMax != w.SigCompressedMax || got.SigPadded != w.SigPadded || got.SigCT != w.SigCT {
+			t.Fatalf("Sizes(%d) = %+v, want encoded sizes %+v", logN, got, w)
+		}
+		if got.TmpKeygen != tmpSizeKeygen(logN) || got.TmpSign != tmpSizeSignDyn(logN) || got.TmpVerify != tmpSizeVerify(logN) {
+			t.Fatalf("Sizes(%d) scratch sizes %+v disagree with the C macros", logN, got)
+		}
+	}
+
+	for _, logN := range []uint{0, 11} {
+		if _, err := Sizes(logN); !errors.Is(err, ErrBadArgument) {
+			t.Fatalf("Sizes(%d): expected ErrBadArgument, got %v", logN, err)
+		}
+	}
+}
+
+func TestSignatureSize(t *testing.T) {
+	for _, logN := range []uint{9, 10} {
+		keyPair, err := GenerateKeyPair(logN)
+		if err != nil {
+			t.Fatalf("Failed to generate key pair: %v", err)
+		}
+		for _, sigType := range []int{SigCompressed, SigPadded, SigCT} {
+			size, err := SignatureSize(logN, sigType)
+			if err != nil {
+				t.Fatalf("SignatureSize(%d, %d) failed: %v", logN, sigType, err)
+			}
+			for i := 0; i < 5; i++ {
+				signature, err := Sign([]byte{byte(i)}, keyPair.PrivateKey, SignatureType(sigType))
+				if err != nil {
+					t.Fatalf("Failed to sign message: %v", err)
+				}
+				if sigType == SigCompressed && len(signature) > size {
+					t.Fatalf("logN %d: compressed signature of %d bytes exceeds maximum %d", logN, len(signature), size)
+				}
+				if sigType != SigCompressed && len(signature) != size {
+					t.Fatalf("logN %d type %d: signature is %d bytes, SignatureSize says %d", logN, sigType, len(signature), size)
+				}
+			}
+		}
+	}
+
+	if _, err := SignatureSize(9, 0); !errors.Is(err, ErrBadArgument) {
+		t.Fatalf("Expected ErrBadArgument for type 0, got %v", err)
+	}
+	if _, err := SignatureSize(11, SigCT); !errors.Is(err, ErrBadArgument) {
+		t.Fatalf("Expected ErrBadArgument for logN 11, got %v", err)
+	}
+}
+
+func TestKeySizes(t *testing.T) {
+	tests := []struct {
+		logN    uint
+		public  int
+		private int
+	}{
+		{0, 0, 0},
+		{1, 5, 7},
+		{2, 8, 13},
+		{3, 15, 25},
+		{4, 29, 49},
+		{5, 57, 97},
+		{6, 113, 177},
+		{7, 225, 353},
+		{8, 449, 641},
+		{9, 897, 1281},
+		{10, 1793, 2305},
+		{11, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := PublicKeySize(tt.logN); got != tt.public {
+			t.Errorf("PublicKeySize(%d) = %d, want %d", tt.logN, got, tt.public)
+		}
+		if got := PrivateKeySize(tt.logN); got != tt.private {
+			t.Errorf("PrivateKeySize(%d) = %d, want %d", tt.logN, got, tt.private)
+		}
+	}
+
+	keyPair, err := GenerateKeyPair(4)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	if len(keyPair.PublicKey) != PublicKeySize(4) || len(keyPair.PrivateKey) != PrivateKeySize(4) {
+		t.Fatalf("Generated key lengths %d/%d disagree with the size functions",
+			len(keyPair.PublicKey), len(keyPair.PrivateKey))
+	}
+}
+
+func TestSignatureMaxLen(t *testing.T) {
+	if SignatureMaxLen(9) != 752 || SignatureMaxLen(10) != 1462 {
+		t.Fatalf("SignatureMaxLen = %d/%d, want 752/1462", SignatureMaxLen(9), SignatureMaxLen(10))
+	}
+	if SignatureMaxLen(0) != 0 || SignatureMaxLen(11) != 0 {
+		t.Fatal("Expected 0 for an invalid logN")
+	}
+
+	iterations := 500
+	if testing.Short() {
+		iterations = 50
+	}
+	message := make([]byte, 64)
+	for _, logN := range []uint{9, 10} {
+		keyPair, err := GenerateKeyPair(logN)
+		if err != nil {
+			t.Fatalf("Failed to generate key pair: %v", err)
+		}
+		for i := 0; i < iterations; i++ {
+			if _, err := rand.Read(message[:i%len(message)]); err != nil {
+				t.Fatalf("Failed to generate message: %v", err)
+			}
+			msg := message[:i%len(message)]
+			signature, err := Sign(msg, keyPair.PrivateKey, SigCompressed)
+			if err != nil {
+				t.Fatalf("Failed to sign message: %v", err)
+			}
+			if n := SignatureLen(signature); n > SignatureMaxLen(logN) || n <= 1+nonceSize {
+				t.Fatalf("logN %d: signature length %d outside (%d, %d]", logN, n, 1+nonceSize, SignatureMaxLen(logN))
+			}
+			if err := Verify(signature, msg, keyPair.PublicKey, SigCompressed); err != nil {
+				t.Fatalf("logN %d iteration %d: signature verification failed: %v", logN, i, err)
+			}
+		}
+	}
+}
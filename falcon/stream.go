@@ -0,0 +1,197 @@
+package falcon
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// StreamChunkSize is the read size used when hashing a message from an
+// io.Reader. It is fixed so that concurrent streams never share mutable
+// state; wrap a slow source in a bufio.Reader to read it in larger
+// pieces.
+const StreamChunkSize = 32 * 1024
+
+// ErrMessageTooLarge is returned when a streamed message exceeds the
+// caller's size limit
+var ErrMessageTooLarge = errors.New("message exceeds size limit")
+
+// absorbReader injects everything read from r into h. If maxBytes is
+// non-negative, reading stops with ErrMessageTooLarge as soon as more
+// than maxBytes bytes have been seen; math.MaxInt64 cannot be exceeded
+// and means no limit.
+func absorbReader(h *PRNGContext, r io.Reader, maxBytes int64) error {
+	if maxBytes == math.MaxInt64 {
+		maxBytes = -1
+	}
+	if maxBytes >= 0 {
+		// Allow one extra byte so an oversized stream can be detected
+		r = io.LimitReader(r, maxBytes+1)
+	}
+
+	buf := make([]byte, StreamChunkSize)
+	var total int64
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			total += int64(n)
+			if maxBytes >= 0 && total > maxBytes {
+				return ErrMessageTooLarge
+			}
+			h.Inject(buf[:n])
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// VerifyWithDigestFunc verifies a signature over a message that the
+// caller supplies in pieces. feed is called once and must pass every
+// chunk of the message, in order, to absorb; chunking does not affect
+// the result. An error returned by feed aborts the verification.
+func VerifyWithDigestFunc(signature, publicKey []byte, sigType int, feed func(absorb func([]byte)) error) error {
+	hashData := &PRNGContext{}
+	if err := verifyStart(hashData, signature); err != nil {
+		return err
+	}
+
+	if err := feed(hashData.Inject); err != nil {
+		return fmt.Errorf("failed to feed message: %w", err)
+	}
+
+	return verifyFinish(signature, publicKey, sigType, hashData)
+}
+
+// SignReaderLimited signs the message read from r, hashing it in chunks
+// so it never has to be held in memory. It fails with ErrMessageTooLarge
+// if r yields more than maxBytes bytes; a negative maxBytes disables the
+// limit. The signature is a regular Falcon signature over the full
+// message and verifies with Verify.
+func SignReaderLimited(r io.Reader, privateKey []byte, sigType int, maxBytes int64) ([]byte, error) {
+	if _, err := GetLogN(privateKey); err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	rng, err := newSystemPRNG()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize RNG: %w", err)
+	}
+
+	hashData := &PRNGContext{}
+	nonce := signStart(rng, hashData)
+	if err := absorbReader(hashData, r, maxBytes); err != nil {
+		return nil, fmt.Errorf("failed to read message: %w", err)
+	}
+
+	return signFinish(rng, privateKey, sigType, hashData, nonce)
+}
+
+// SignStream signs the message read from r until EOF, hashing it in
+// chunks of StreamChunkSize so that messages of any size can be signed
+// without loading them into memory. The result is identical to what Sign
+// would produce for the same bytes and randomness, and verifies with
+// Verify.
+func SignStream(r io.Reader, privateKey []byte, sigType int) ([]byte, error) {
+	return SignReaderLimited(r, privateKey, sigType, -1)
+}
+
+// VerifyReaderLimited verifies a signature over the message read from r.
+// It fails with ErrMessageTooLarge, before any lattice work, if r yields
+// more than maxBytes bytes; a negative maxBytes disables the limit.
+func VerifyReaderLimited(signature []byte, r io.Reader, publicKey []byte, sigType int, maxBytes int64) error {
+	hashData := &PRNGContext{}
+	if err := verifyStart(hashData, signature); err != nil {
+		return err
+	}
+
+	if err := absorbReader(hashData, r, maxBytes); err != nil {
+		return fmt.Errorf("failed to read message: %w", err)
+	}
+
+	return verifyFinish(signature, publicKey, sigType, hashData)
+}
+
+// VerifyStream verifies a signature over the message read from r until
+// EOF, hashing it in chunks of StreamChunkSize. It accepts and rejects
+// exactly what Verify does for the same bytes held in memory.
+func VerifyStream(signature []byte, r io.Reader, publicKey []byte, sigType int) error {
+	return VerifyReaderLimited(signature, r, publicKey, sigType, -1)
+}
+
+// VerifySegments verifies a stream of fixed-size segments, each
+// immediately followed by its own signature over that segment. Only one
+// segment is held in memory at a time. Every segment must be exactly
+// segmentSize bytes; signatures of the compressed type are delimited by
+// decoding them. The first failure is returned naming its zero-based
+// segment index.
+//
+// Each signature covers only its segment, so this does not detect
+// reordered or dropped segments; embed a sequence number in the
+// segment data if that matters.
+func VerifySegments(r io.Reader, publicKey []byte, sigType int, segmentSize int) error {
+	logN, err := checkPublicKey(publicKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	if segmentSize <= 0 {
+		return newError(ErrBadArg, "segment size must be positive")
+	}
+	maxSig, err := sigBufferSize(logN, sigType)
+	if err != nil {
+		return err
+	}
+
+	br := bufio.NewReaderSize(r, maxSig)
+	segment := make([]byte, segmentSize)
+	tmp := make([]byte, tmpSizeVerify(logN))
+	for i := 0; ; i++ {
+		if _, err := io.ReadFull(br, segment); err != nil {
+			if err == io.EOF {
+				if i == 0 {
+					return fmt.Errorf("%w: no segments", ErrBadLength)
+				}
+				return nil
+			}
+			return fmt.Errorf("segment %d: %w", i, err)
+		}
+
+		sig, err := readSegmentSignature(br, sigType, logN, maxSig)
+		if err != nil {
+			return fmt.Errorf("segment %d: %w", i, err)
+		}
+		if err := verifyWithTmp(sig, segment, publicKey, sigType, tmp); err != nil {
+			return fmt.Errorf("segment %d: %w", i, err)
+		}
+	}
+}
+
+// readSegmentSignature reads exactly one signature from br, using a
+// SignatureScanner to find where it ends
+func readSegmentSignature(br *bufio.Reader, sigType int, logN uint, maxSig int) ([]byte, error) {
+	peek, err := br.Peek(maxSig)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	s := NewSignatureScanner(sigType, logN)
+	s.Write(peek)
+	done, err := s.Complete()
+	if err != nil {
+		return nil, fmt.Errorf("malformed signature: %w", err)
+	}
+	if !done {
+		return nil, fmt.Errorf("truncated signature: %w", io.ErrUnexpectedEOF)
+	}
+
+	sig := s.Signature()
+	if _, err := br.Discard(len(sig)); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
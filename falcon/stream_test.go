@@ -0,0 +1,257 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestVerifyWithDigestFunc(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	message := make([]byte, 10000)
+	for i := range message {
+		message[i] = byte(i * 7)
+	}
+
+	for _, sigType := range []int{SigCompressed, SigPadded, SigCT} {
+		signature, err := Sign(message, keyPair.PrivateKey, SignatureType(sigType))
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		if err := Verify(signature, message, keyPair.PublicKey, SignatureType(sigType)); err != nil {
+			t.Fatalf("One-shot verification failed: %v", err)
+		}
+
+		feed := func(absorb func([]byte)) error {
+			for off := 0; off < len(message); off += 777 {
+				end := off + 777
+				if end > len(message) {
+					end = len(message)
+				}
+				absorb(message[off:end])
+			}
+			return nil
+		}
+		if err := VerifyWithDigestFunc(signature, keyPair.PublicKey, sigType, feed); err != nil {
+			t.Fatalf("Chunked verification failed for type %d: %v", sigType, err)
+		}
+
+		// Dropping the last chunk must make verification fail
+		truncated := func(absorb func([]byte)) error {
+			absorb(message[:len(message)-1])
+			return nil
+		}
+		if err := VerifyWithDigestFunc(signature, keyPair.PublicKey, sigType, truncated); err == nil {
+			t.Fatalf("Verification succeeded for truncated message, type %d", sigType)
+		}
+	}
+
+	errFeed := errors.New("read failed")
+	signature, err := Sign(message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	err = VerifyWithDigestFunc(signature, keyPair.PublicKey, SigCompressed, func(func([]byte)) error {
+		return errFeed
+	})
+	if !errors.Is(err, errFeed) {
+		t.Fatalf("Expected feed error, got %v", err)
+	}
+}
+
+// endlessReader yields an unbounded stream of bytes and counts them
+type endlessReader struct {
+	read int64
+}
+
+func (e *endlessReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(e.read + int64(i))
+	}
+	e.read += int64(len(p))
+	return len(p), nil
+}
+
+func TestReaderLimited(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	message := bytes.Repeat([]byte("bounded stream "), 10000)
+
+	signature, err := SignReaderLimited(bytes.NewReader(message), keyPair.PrivateKey, SigCompressed, int64(len(message)))
+	if err != nil {
+		t.Fatalf("Failed to sign from reader: %v", err)
+	}
+	if err := Verify(signature, message, keyPair.PublicKey, SigCompressed); err != nil {
+		t.Fatalf("Streamed signature failed one-shot verification: %v", err)
+	}
+	if err := VerifyReaderLimited(signature, bytes.NewReader(message), keyPair.PublicKey, SigCompressed, int64(len(message))); err != nil {
+		t.Fatalf("Streamed verification failed: %v", err)
+	}
+	if err := VerifyReaderLimited(signature, bytes.NewReader(message), keyPair.PublicKey, SigCompressed, -1); err != nil {
+		t.Fatalf("Unlimited streamed verification failed: %v", err)
+	}
+
+	// The largest limit must not overflow into an empty message
+	maxed, err := SignReaderLimited(bytes.NewReader(message), keyPair.PrivateKey, SigCompressed, math.MaxInt64)
+	if err != nil {
+		t.Fatalf("Failed to sign from reader: %v", err)
+	}
+	if err := Verify(maxed, message, keyPair.PublicKey, SigCompressed); err != nil {
+		t.Fatalf("Signature with limit MaxInt64 does not cover the message: %v", err)
+	}
+	if err := VerifyReaderLimited(signature, bytes.NewReader(message), keyPair.PublicKey, SigCompressed, math.MaxInt64); err != nil {
+		t.Fatalf("Streamed verification with limit MaxInt64 failed: %v", err)
+	}
+	if err := VerifyReaderLimited(maxed, bytes.NewReader(nil), keyPair.PublicKey, SigCompressed, math.MaxInt64); !errors.Is(err, ErrBadSignature) {
+		t.Fatalf("Expected ErrBadSignature for the empty message, got %v", err)
+	}
+
+	// One byte over the limit must be rejected
+	err = VerifyReaderLimited(signature, bytes.NewReader(message), keyPair.PublicKey, SigCompressed, int64(len(message))-1)
+	if !errors.Is(err, ErrMessageTooLarge) {
+		t.Fatalf("Expected ErrMessageTooLarge, got %v", err)
+	}
+
+	// An endless stream must stop shortly after the limit is crossed
+	const limit = 1 << 20
+	src := &endlessReader{}
+	err = VerifyReaderLimited(signature, src, keyPair.PublicKey, SigCompressed, limit)
+	if !errors.Is(err, ErrMessageTooLarge) {
+		t.Fatalf("Expected ErrMessageTooLarge, got %v", err)
+	}
+	if src.read > limit+int64(StreamChunkSize) {
+		t.Fatalf("Read %d bytes from an endless stream with limit %d", src.read, limit)
+	}
+
+	_, err = SignReaderLimited(io.LimitReader(&endlessReader{}, limit+1), keyPair.PrivateKey, SigCompressed, limit)
+	if !errors.Is(err, ErrMessageTooLarge) {
+		t.Fatalf("Expected ErrMessageTooLarge when signing, got %v", err)
+	}
+}
+
+func TestVerifySegments(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	const segmentSize = 100
+	for _, sigType := range []int{SigCompressed, SigPadded, SigCT} {
+		var stream []byte
+		var offsets []int
+		for i := 0; i < 4; i++ {
+			segment := bytes.Repeat([]byte{byte('a' + i)}, segmentSize)
+			signature, err := Sign(segment, keyPair.PrivateKey, SignatureType(sigType))
+			if err != nil {
+				t.Fatalf("Failed to sign segment: %v", err)
+			}
+			offsets = append(offsets, len(stream))
+			stream = append(stream, segment...)
+			stream = append(stream, signature...)
+		}
+
+		if err := VerifySegments(bytes.NewReader(stream), keyPair.PublicKey, sigType, segmentSize); err != nil {
+			t.Fatalf("Type %d: failed to verify segments: %v", sigType, err)
+		}
+
+		corrupted := append([]byte{}, stream...)
+		corrupted[offsets[2]+10] ^= 1
+		err := VerifySegments(bytes.NewReader(corrupted), keyPair.PublicKey, sigType, segmentSize)
+		if err == nil || !strings.HasPrefix(err.Error(), "segment 2:") {
+			t.Fatalf("Type %d: expected failure naming segment 2, got %v", sigType, err)
+		}
+
+		err = VerifySegments(bytes.NewReader(stream[:len(stream)-3]), keyPair.PublicKey, sigType, segmentSize)
+		if err == nil || !strings.HasPrefix(err.Error(), "segment 3:") {
+			t.Fatalf("Type %d: expected truncation error naming segment 3, got %v", sigType, err)
+		}
+	}
+
+	if err := VerifySegments(bytes.NewReader(nil), keyPair.PublicKey, SigCompressed, segmentSize); err == nil {
+		t.Fatal("Expected error for empty stream")
+	}
+	if err := VerifySegments(bytes.NewReader(nil), keyPair.PublicKey, SigCompressed, 0); err == nil {
+		t.Fatal("Expected error for zero segment size")
+	}
+}
+
+func TestSignStream(t *testing.T) {
+	defer func() { seedFromSystem = (*PRNGContext).InitFromSystem }()
+
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	message := bytes.Repeat([]byte("large streamed message "), 200000)
+
+	// With the same randomness the streamed and one-shot signatures match
+	seedFromSystem = func(p *PRNGContext) error {
+		p.InitFromSeed([]byte("stream parity seed"))
+		return nil
+	}
+	want, err := Sign(message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	got, err := SignStream(bytes.NewReader(message), keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign stream: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatal("Streamed signature differs from Sign on the same bytes")
+	}
+	seedFromSystem = (*PRNGContext).InitFromSystem
+
+	if err := Verify(got, message, keyPair.PublicKey, SigCompressed); err != nil {
+		t.Fatalf("Streamed signature failed verification: %v", err)
+	}
+
+	if _, err := SignStream(bytes.NewReader(message), keyPair.PrivateKey[:1], SigCompressed); err == nil {
+		t.Fatal("Expected error for invalid private key")
+	}
+}
+
+func TestVerifyStream(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	message := bytes.Repeat([]byte("downloaded artifact "), 250000)
+	signature, err := Sign(message, keyPair.PrivateKey, SigPadded)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	tampered := append([]byte{}, message...)
+	tampered[len(tampered)/2] ^= 1
+
+	tests := []struct {
+		name      string
+		signature []byte
+		message   []byte
+	}{
+		{"valid", signature, message},
+		{"tampered message", signature, tampered},
+		{"truncated message", signature, message[:len(message)-1]},
+		{"truncated signature", signature[:len(signature)-1], message},
+	}
+	for _, tt := range tests {
+		want := Verify(tt.signature, tt.message, keyPair.PublicKey, SigPadded)
+		got := VerifyStream(tt.signature, bytes.NewReader(tt.message), keyPair.PublicKey, SigPadded)
+		if (got == nil) != (want == nil) {
+			t.Fatalf("%s: VerifyStream = %v, Verify = %v", tt.name, got, want)
+		}
+	}
+}
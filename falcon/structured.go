@@ -0,0 +1,54 @@
+package falcon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// canonicalEncode produces a deterministic JSON encoding of v. The value
+// is first marshaled with encoding/json, then decoded into generic maps
+// and slices and marshaled again, so object keys (including struct
+// fields) always appear in sorted order and numbers keep their original
+// textual form. HTML escaping is disabled and no trailing newline is
+// emitted.
+func canonicalEncode(v interface{}) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var generic interface{}
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(generic); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// SignStruct signs the canonical JSON encoding of v. Map ordering and
+// struct field order do not affect the signed bytes.
+func SignStruct(v interface{}, privateKey []byte, sigType int) ([]byte, error) {
+	data, err := canonicalEncode(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode value: %w", err)
+	}
+	return Sign(data, privateKey, SignatureType(sigType))
+}
+
+// VerifyStruct verifies a signature produced by SignStruct over v
+func VerifyStruct(signature []byte, v interface{}, publicKey []byte, sigType int) error {
+	data, err := canonicalEncode(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode value: %w", err)
+	}
+	return Verify(signature, data, publicKey, SignatureType(sigType))
+}
@@ -0,0 +1,79 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"testing"
+)
+
+type structuredRecord struct {
+	ID     int               `json:"id"`
+	Labels map[string]string `json:"labels"`
+	Counts map[string]int    `json:"counts"`
+}
+
+// Same fields as structuredRecord, declared in a different order
+type structuredRecordReordered struct {
+	Counts map[string]int    `json:"counts"`
+	Labels map[string]string `json:"labels"`
+	ID     int               `json:"id"`
+}
+
+func TestSignStruct(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	record := structuredRecord{
+		ID:     42,
+		Labels: map[string]string{"zeta": "z", "alpha": "a", "mid": "<m>"},
+		Counts: map[string]int{"b": 2, "a": 1, "c": 3},
+	}
+
+	// The encoding must not depend on map iteration order
+	first, err := canonicalEncode(record)
+	if err != nil {
+		t.Fatalf("Failed to encode record: %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		again, err := canonicalEncode(record)
+		if err != nil {
+			t.Fatalf("Failed to encode record: %v", err)
+		}
+		if !bytes.Equal(first, again) {
+			t.Fatalf("Canonical encoding not stable:\n%s\n%s", first, again)
+		}
+	}
+
+	signature, err := SignStruct(record, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign struct: %v", err)
+	}
+
+	// A freshly built copy with maps filled in another order must verify
+	copyRecord := structuredRecord{
+		ID:     42,
+		Labels: map[string]string{"mid": "<m>", "zeta": "z", "alpha": "a"},
+		Counts: map[string]int{"c": 3, "b": 2, "a": 1},
+	}
+	if err := VerifyStruct(signature, copyRecord, keyPair.PublicKey, SigCompressed); err != nil {
+		t.Fatalf("Failed to verify struct: %v", err)
+	}
+
+	// Field declaration order does not matter either
+	reordered := structuredRecordReordered{
+		Counts: record.Counts,
+		Labels: record.Labels,
+		ID:     record.ID,
+	}
+	if err := VerifyStruct(signature, reordered, keyPair.PublicKey, SigCompressed); err != nil {
+		t.Fatalf("Failed to verify reordered struct: %v", err)
+	}
+
+	copyRecord.Counts["a"] = 100
+	if err := VerifyStruct(signature, copyRecord, keyPair.PublicKey, SigCompressed); err == nil {
+		t.Fatal("Verification succeeded for modified struct")
+	}
+}
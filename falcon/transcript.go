@@ -0,0 +1,133 @@
+package falcon
+
+import "fmt"
+
+// SignWithContext256 signs the data already absorbed into ctx, such as
+// a protocol transcript followed by the message.
+//
+// ctx must have been set up with Init and Inject and must still be in
+// absorb mode: Flip or Extract must not have been called on it. ctx is
+// not modified; the signature works on a copy. A nil ctx fails with
+// ErrBadArgument and a flipped one with ErrContextFlipped.
+//
+// The nonce is injected after the absorbed data, so the signed hash is
+// SHAKE256(data || nonce) rather than the SHAKE256(nonce || message) of
+// a plain signature. Signatures from this function verify only with
+// VerifyWithContext256, never with Verify.
+func SignWithContext256(ctx *PRNGContext, privateKey []byte, sigType int) ([]byte, error) {
+	if err := checkAbsorbing(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := GetLogN(privateKey); err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	hashData := *ctx
+	return signAbsorbed(&hashData, privateKey, sigType)
+}
+
+// checkAbsorbing rejects a nil hash context or one already flipped to
+// output mode
+func checkAbsorbing(ctx *PRNGContext) error {
+	if ctx == nil {
+		return newError(ErrBadArg, "nil PRNG context")
+	}
+	if ctx.flipped {
+		return ErrContextFlipped
+	}
+	return nil
+}
+
+// signAbsorbed signs the data absorbed into hashData followed by a fresh
+// nonce, so the signed hash is SHAKE256(data || nonce). hashData must be
+// in absorb mode and is consumed.
+func signAbsorbed(hashData *PRNGContext, privateKey []byte, sigType int) ([]byte, error) {
+	rng, err := newSystemPRNG()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize RNG: %w", err)
+	}
+
+	// signStart draws the nonce; its own hash context is not used
+	nonce := signStart(rng, &PRNGContext{})
+	hashData.Inject(nonce)
+	return signFinish(rng, privateKey, sigType, hashData, nonce)
+}
+
+// verifyAbsorbed verifies a signature made by signAbsorbed over the data
+// absorbed into hashData, which is consumed
+func verifyAbsorbed(signature []byte, hashData *PRNGContext, publicKey []byte, sigType int) error {
+	if _, err := checkSignature(signature, sigType); err != nil {
+		return fmt.Errorf("malformed signature: %w", err)
+	}
+	hashData.Inject(signature[1 : 1+nonceSize])
+	return verifyFinish(signature, publicKey, sigType, hashData)
+}
+
+// VerifyWithContext256 verifies a signature produced by
+// SignWithContext256 over the data absorbed into ctx. The same
+// requirements on ctx apply: initialized, absorbed, not yet flipped. ctx
+// is not modified.
+func VerifyWithContext256(signature []byte, ctx *PRNGContext, publicKey []byte, sigType int) error {
+	if err := checkAbsorbing(ctx); err != nil {
+		return err
+	}
+	hashData := *ctx
+	return verifyAbsorbed(signature, &hashData, publicKey, sigType)
+}
+
+// MaxContextSize is the longest context string accepted by
+// SignWithContext and VerifyWithContext
+const MaxContextSize = 255
+
+var errContextTooLong = newError(ErrBadArg, "context string longer than 255 bytes")
+
+// contextTag starts the hashed data of every context signature. A plain
+// signature hashes its random nonce first, so it can only be taken for a
+// context signature, or the reverse, if that nonce begins with the tag.
+var contextTag = []byte("falcon-go context signature v1")
+
+// contextHash returns a hash context that has absorbed
+// contextTag || len(context) || context || message. The one-byte length
+// keeps the context/message boundary unambiguous.
+func contextHash(message, context []byte) (*PRNGContext, error) {
+	if len(context) > MaxContextSize {
+		return nil, errContextTooLong
+	}
+	hashData := &PRNGContext{}
+	hashData.Init()
+	hashData.Inject(contextTag)
+	hashData.Inject([]byte{byte(len(context))})
+	hashData.Inject(context)
+	hashData.Inject(message)
+	return hashData, nil
+}
+
+// SignWithContext signs message under a context string of at most
+// MaxContextSize bytes, for domain separation when one key serves
+// several purposes. The signed hash is
+// SHAKE256(tag || len(context) || context || message || nonce), where
+// tag is a fixed 30-byte string. A signature made under one context
+// fails verification under any other, including the empty context.
+// Because plain signatures hash the nonce first, context signatures do
+// not verify with Verify for any message, and plain signatures do not
+// verify with VerifyWithContext. The wire format is unchanged.
+func SignWithContext(message, context, privateKey []byte, sigType int) ([]byte, error) {
+	if _, err := checkPrivateKey(privateKey); err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	hashData, err := contextHash(message, context)
+	if err != nil {
+		return nil, err
+	}
+	return signAbsorbed(hashData, privateKey, sigType)
+}
+
+// VerifyWithContext verifies a signature made by SignWithContext under
+// the same context string
+func VerifyWithContext(signature, message, context, publicKey []byte, sigType int) error {
+	hashData, err := contextHash(message, context)
+	if err != nil {
+		return err
+	}
+	return verifyAbsorbed(signature, hashData, publicKey, sigType)
+}
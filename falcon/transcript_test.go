@@ -0,0 +1,157 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestVerifyWithContext256(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	message := []byte("transcript-bound message")
+	absorbed := func(parts ...[]byte) *PRNGContext {
+		ctx := &PRNGContext{}
+		ctx.Init()
+		for _, p := range parts {
+			ctx.Inject(p)
+		}
+		return ctx
+	}
+
+	// With only the message absorbed, the context variant accepts and
+	// rejects exactly where plain Verify does on its own signatures
+	plain, err := Sign(message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	ctx := absorbed(message)
+	viaContext, err := SignWithContext256(ctx, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign context: %v", err)
+	}
+
+	if err := Verify(plain, message, keyPair.PublicKey, SigCompressed); err != nil {
+		t.Fatalf("Plain verification failed: %v", err)
+	}
+	if err := VerifyWithContext256(viaContext, ctx, keyPair.PublicKey, SigCompressed); err != nil {
+		t.Fatalf("Context verification failed: %v", err)
+	}
+
+	tampered := []byte("transcript-bound messagE")
+	if err := Verify(plain, tampered, keyPair.PublicKey, SigCompressed); err == nil {
+		t.Fatal("Plain verification succeeded for modified message")
+	}
+	if err := VerifyWithContext256(viaContext, absorbed(tampered), keyPair.PublicKey, SigCompressed); err == nil {
+		t.Fatal("Context verification succeeded for modified message")
+	}
+
+	// The nonce goes after the absorbed data, so the two schemes do not
+	// accept each other's signatures
+	if err := Verify(viaContext, message, keyPair.PublicKey, SigCompressed); err == nil {
+		t.Fatal("Plain verification accepted a context signature")
+	}
+	if err := VerifyWithContext256(plain, ctx, keyPair.PublicKey, SigCompressed); err == nil {
+		t.Fatal("Context verification accepted a plain signature")
+	}
+
+	// Binding a transcript: a different transcript prefix must fail, and
+	// the caller's context is left untouched so it can be reused
+	bound := absorbed([]byte("session-1"), message)
+	sig, err := SignWithContext256(bound, keyPair.PrivateKey, SigCT)
+	if err != nil {
+		t.Fatalf("Failed to sign context: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := VerifyWithContext256(sig, bound, keyPair.PublicKey, SigCT); err != nil {
+			t.Fatalf("Context verification failed on pass %d: %v", i, err)
+		}
+	}
+	if err := VerifyWithContext256(sig, absorbed([]byte("session-2"), message), keyPair.PublicKey, SigCT); err == nil {
+		t.Fatal("Verification succeeded under another transcript")
+	}
+
+	if err := VerifyWithContext256(sig[:10], bound, keyPair.PublicKey, SigCT); err == nil {
+		t.Fatal("Expected error for truncated signature")
+	}
+
+	flipped := absorbed(message)
+	flipped.Flip()
+	if _, err := SignWithContext256(flipped, keyPair.PrivateKey, SigCT); !errors.Is(err, ErrContextFlipped) {
+		t.Errorf("Sign with flipped context: expected ErrContextFlipped, got %v", err)
+	}
+	if err := VerifyWithContext256(sig, flipped, keyPair.PublicKey, SigCT); !errors.Is(err, ErrContextFlipped) {
+		t.Errorf("Verify with flipped context: expected ErrContextFlipped, got %v", err)
+	}
+	if _, err := SignWithContext256(nil, keyPair.PrivateKey, SigCT); !errors.Is(err, ErrBadArgument) {
+		t.Errorf("Sign with nil context: expected ErrBadArgument, got %v", err)
+	}
+	if err := VerifyWithContext256(sig, nil, keyPair.PublicKey, SigCT); !errors.Is(err, ErrBadArgument) {
+		t.Errorf("Verify with nil context: expected ErrBadArgument, got %v", err)
+	}
+}
+
+func TestSignWithContext(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	message := []byte("shared key, separate purposes")
+
+	signature, err := SignWithContext(message, []byte("payments"), keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign with context: %v", err)
+	}
+	if err := VerifyWithContext(signature, message, []byte("payments"), keyPair.PublicKey, SigCompressed); err != nil {
+		t.Fatalf("Signature failed verification under its context: %v", err)
+	}
+
+	// Every other context, and plain verification, must fail
+	for _, other := range [][]byte{[]byte("login"), []byte("payment"), nil} {
+		if err := VerifyWithContext(signature, message, other, keyPair.PublicKey, SigCompressed); !errors.Is(err, ErrBadSignature) {
+			t.Fatalf("Context %q: expected ErrBadSignature, got %v", other, err)
+		}
+	}
+	if err := Verify(signature, message, keyPair.PublicKey, SigCompressed); !errors.Is(err, ErrBadSignature) {
+		t.Fatalf("Expected plain Verify to fail, got %v", err)
+	}
+
+	// Nor does plain verification accept it over the prefixed message,
+	// and a plain signature over that message is no context signature
+	prefixed := append(append([]byte{}, contextTag...), byte(len("payments")))
+	prefixed = append(append(prefixed, "payments"...), message...)
+	for _, m := range [][]byte{prefixed, prefixed[len(contextTag):]} {
+		if err := Verify(signature, m, keyPair.PublicKey, SigCompressed); !errors.Is(err, ErrBadSignature) {
+			t.Fatalf("Expected plain Verify over the prefixed message to fail, got %v", err)
+		}
+		plain, err := Sign(m, keyPair.PrivateKey, SigCompressed)
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		if err := VerifyWithContext(plain, message, []byte("payments"), keyPair.PublicKey, SigCompressed); !errors.Is(err, ErrBadSignature) {
+			t.Fatalf("Expected a plain signature to fail context verification, got %v", err)
+		}
+	}
+
+	// The length prefix keeps the context/message boundary unambiguous
+	shifted, err := SignWithContext([]byte("b"), []byte("a"), keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign with context: %v", err)
+	}
+	if err := VerifyWithContext(shifted, []byte("ab"), nil, keyPair.PublicKey, SigCompressed); err == nil {
+		t.Fatal("Signature verified with the context moved into the message")
+	}
+
+	long := []byte(strings.Repeat("x", MaxContextSize+1))
+	if _, err := SignWithContext(message, long, keyPair.PrivateKey, SigCompressed); !errors.Is(err, ErrBadArgument) {
+		t.Fatalf("Expected ErrBadArgument for an oversized context, got %v", err)
+	}
+	if err := VerifyWithContext(signature, message, long, keyPair.PublicKey, SigCompressed); !errors.Is(err, ErrBadArgument) {
+		t.Fatalf("Expected ErrBadArgument for an oversized context, got %v", err)
+	}
+}
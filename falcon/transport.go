@@ -0,0 +1,66 @@
+package falcon
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// transportMACSize is the length of the HMAC-SHA256 tag ending a frame
+const transportMACSize = sha256.Size
+
+// ErrTransportMAC is returned by VerifyWithTransportMAC when the frame's
+// HMAC does not match, meaning the frame was corrupted in transit
+var ErrTransportMAC = errors.New("transport MAC mismatch")
+
+// transportMAC computes the frame tag over data
+func transportMAC(macKey, data []byte) []byte {
+	m := hmac.New(sha256.New, macKey)
+	m.Write(data)
+	return m.Sum(nil)
+}
+
+// SealTransportFrame builds a frame carrying message and its signature
+// protected by an HMAC-SHA256 tag under macKey. The layout is
+//
+//	len(message) (4 bytes, big-endian) || message || signature || tag
+//
+// where tag covers everything before it.
+func SealTransportFrame(message, signature, macKey []byte) ([]byte, error) {
+	if uint64(len(message)) > math.MaxUint32 {
+		return nil, newError(ErrBadArg, "message too large for transport frame")
+	}
+	frame := make([]byte, 0, 4+len(message)+len(signature)+transportMACSize)
+	frame = binary.BigEndian.AppendUint32(frame, uint32(len(message)))
+	frame = append(frame, message...)
+	frame = append(frame, signature...)
+	return append(frame, transportMAC(macKey, frame)...), nil
+}
+
+// VerifyWithTransportMAC checks a frame produced by SealTransportFrame.
+// The HMAC is checked first, so a corrupted frame is rejected with
+// ErrTransportMAC before any lattice verification is done; the Falcon
+// signature is then verified over the embedded message. The HMAC only
+// guards against transport corruption and is no substitute for the
+// signature.
+func VerifyWithTransportMAC(frame, publicKey, macKey []byte, sigType int) error {
+	if len(frame) < 4+transportMACSize {
+		return fmt.Errorf("%w: frame too short", ErrBadLength)
+	}
+
+	body, tag := frame[:len(frame)-transportMACSize], frame[len(frame)-transportMACSize:]
+	if !hmac.Equal(tag, transportMAC(macKey, body)) {
+		return ErrTransportMAC
+	}
+
+	msgLen := binary.BigEndian.Uint32(body)
+	if uint64(msgLen) > uint64(len(body)-4) {
+		return fmt.Errorf("%w: message length %d exceeds frame", ErrBadLength, msgLen)
+	}
+	message := body[4 : 4+msgLen]
+	signature := body[4+msgLen:]
+	return Verify(signature, message, publicKey, SignatureType(sigType))
+}
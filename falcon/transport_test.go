@@ -0,0 +1,65 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVerifyWithTransportMAC(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	macKey := []byte("transport key")
+
+	message := []byte("framed payload")
+	signature, err := Sign(message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	frame, err := SealTransportFrame(message, signature, macKey)
+	if err != nil {
+		t.Fatalf("Failed to seal frame: %v", err)
+	}
+
+	if err := VerifyWithTransportMAC(frame, keyPair.PublicKey, macKey, SigCompressed); err != nil {
+		t.Fatalf("Failed to verify frame: %v", err)
+	}
+
+	// Corruption anywhere in the frame is caught by the MAC
+	for _, i := range []int{0, 5, len(frame) / 2, len(frame) - 1} {
+		corrupted := append([]byte{}, frame...)
+		corrupted[i] ^= 0x01
+		err := VerifyWithTransportMAC(corrupted, keyPair.PublicKey, macKey, SigCompressed)
+		if !errors.Is(err, ErrTransportMAC) {
+			t.Fatalf("Byte %d corrupted: expected ErrTransportMAC, got %v", i, err)
+		}
+	}
+	if err := VerifyWithTransportMAC(frame, keyPair.PublicKey, []byte("other key"), SigCompressed); !errors.Is(err, ErrTransportMAC) {
+		t.Fatalf("Expected ErrTransportMAC for wrong MAC key, got %v", err)
+	}
+
+	// A valid MAC does not make a bad signature acceptable
+	other, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	forged, err := Sign(message, other.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	frame, err = SealTransportFrame(message, forged, macKey)
+	if err != nil {
+		t.Fatalf("Failed to seal frame: %v", err)
+	}
+	err = VerifyWithTransportMAC(frame, keyPair.PublicKey, macKey, SigCompressed)
+	if err == nil || errors.Is(err, ErrTransportMAC) {
+		t.Fatalf("Expected signature failure, got %v", err)
+	}
+
+	if err := VerifyWithTransportMAC([]byte{1, 2, 3}, keyPair.PublicKey, macKey, SigCompressed); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("Expected ErrBadFormat for short frame, got %v", err)
+	}
+}
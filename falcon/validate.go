@@ -0,0 +1,120 @@
+package falcon
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// maxKeygenAttempts bounds how many key pairs GenerateValidatedKeyPair
+// generates before giving up
+const maxKeygenAttempts = 3
+
+// selfTestMessage is signed when checking a freshly generated key pair
+var selfTestMessage = []byte("falcon-go key pair self-test")
+
+// ErrKeyMismatch is returned when a public key does not belong to the
+// private key it is paired with
+var ErrKeyMismatch = errors.New("public key does not match private key")
+
+// checkKeyPair confirms that the public key matches the private key and
+// that a sign/verify round trip succeeds
+func checkKeyPair(kp *KeyPair) error {
+	derived, err := derivePublicKey(kp.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("failed to derive public key: %w", err)
+	}
+	if !bytes.Equal(derived, kp.PublicKey) {
+		return ErrKeyMismatch
+	}
+
+	signature, err := Sign(selfTestMessage, kp.PrivateKey, SigCompressed)
+	if err != nil {
+		return fmt.Errorf("self-test signing failed: %w", err)
+	}
+	if err := Verify(signature, selfTestMessage, kp.PublicKey, SigCompressed); err != nil {
+		return fmt.Errorf("self-test verification failed: %w", err)
+	}
+	return nil
+}
+
+// ValidateKeyPair checks that publicKey and privateKey belong together,
+// for instance after loading them from separate files. Both keys are
+// checked structurally, then a random message is signed with the private
+// key and verified with the public key; a pair that fails the round trip
+// yields ErrKeyMismatch.
+func ValidateKeyPair(publicKey, privateKey []byte) error {
+	pubLogN, err := checkPublicKey(publicKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	privLogN, err := checkPrivateKey(privateKey)
+	if err != nil {
+		return fmt.Errorf("invalid private key: %w", err)
+	}
+	if pubLogN != privLogN {
+		return fmt.Errorf("%w: degrees %d and %d differ", ErrKeyMismatch, 1<<pubLogN, 1<<privLogN)
+	}
+
+	rng, err := newSystemPRNG()
+	if err != nil {
+		return fmt.Errorf("failed to initialize RNG: %w", err)
+	}
+	message := make([]byte, 32)
+	rng.Extract(message)
+
+	signature, err := signWithPRNG(rng, message, privateKey, SigCompressed)
+	if err != nil {
+		return fmt.Errorf("test signing failed: %w", err)
+	}
+	if err := Verify(signature, message, publicKey, SigCompressed); err != nil {
+		if errors.Is(err, ErrBadSignature) {
+			return ErrKeyMismatch
+		}
+		return fmt.Errorf("test verification failed: %w", err)
+	}
+	return nil
+}
+
+// VerifyKeyPair checks that publicKey is the public key of privateKey by
+// deriving the public key and comparing the encodings. Unlike
+// ValidateKeyPair it involves no signing, and it takes the private key
+// first. A mismatch yields ErrKeyMismatch; malformed keys yield errors
+// matching ErrBadFormat.
+func VerifyKeyPair(privateKey, publicKey []byte) error {
+	if _, err := checkPublicKey(publicKey); err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	derived, err := DerivePublicKey(privateKey)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(derived, publicKey) {
+		return ErrKeyMismatch
+	}
+	return nil
+}
+
+// keyPairCheck is the check run by GenerateValidatedKeyPair; tests
+// replace it to force the retry path
+var keyPairCheck = checkKeyPair
+
+// GenerateValidatedKeyPair generates a key pair and self-checks it
+// (public key re-derivation plus a sign/verify round trip), regenerating
+// up to a small fixed number of times if the check fails. Only a pair
+// that passes the check is returned.
+func GenerateValidatedKeyPair(logN uint) (*KeyPair, error) {
+	var lastErr error
+	for attempt := 0; attempt < maxKeygenAttempts; attempt++ {
+		kp, err := GenerateKeyPair(logN)
+		if err != nil {
+			return nil, err
+		}
+		if lastErr = keyPairCheck(kp); lastErr == nil {
+			return kp, nil
+		}
+		logWarn("generated key pair failed self-check, regenerating",
+			"logN", logN, "attempt", attempt+1, "error", lastErr)
+	}
+	return nil, fmt.Errorf("key pair failed validation after %d attempts: %w", maxKeygenAttempts, lastErr)
+}
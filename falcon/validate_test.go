@@ -0,0 +1,104 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestGenerateValidatedKeyPair(t *testing.T) {
+	for _, logN := range []uint{9, 10} {
+		t.Run(fmt.Sprintf("logN=%d", logN), func(t *testing.T) {
+			for i := 0; i < 3; i++ {
+				keyPair, err := GenerateValidatedKeyPair(logN)
+				if err != nil {
+					t.Fatalf("Failed to generate validated key pair: %v", err)
+				}
+
+				message := []byte(fmt.Sprintf("round trip %d", i))
+				for _, sigType := range []int{SigCompressed, SigPadded, SigCT} {
+					signature, err := Sign(message, keyPair.PrivateKey, SignatureType(sigType))
+					if err != nil {
+						t.Fatalf("Failed to sign message: %v", err)
+					}
+					if err := Verify(signature, message, keyPair.PublicKey, SignatureType(sigType)); err != nil {
+						t.Fatalf("Signature verification failed: %v", err)
+					}
+				}
+			}
+		})
+	}
+
+	if _, err := GenerateValidatedKeyPair(0); err == nil {
+		t.Fatal("Expected error for invalid logN")
+	}
+}
+
+func TestCheckKeyPairMismatch(t *testing.T) {
+	a, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	b, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	if err := checkKeyPair(&KeyPair{PublicKey: b.PublicKey, PrivateKey: a.PrivateKey}); err == nil {
+		t.Fatal("Expected mismatched key pair to fail the self-check")
+	}
+}
+
+func TestValidateKeyPair(t *testing.T) {
+	a, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	b, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	c, err := GenerateKeyPair(10)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	if err := ValidateKeyPair(a.PublicKey, a.PrivateKey); err != nil {
+		t.Fatalf("Matching key pair rejected: %v", err)
+	}
+	if err := ValidateKeyPair(b.PublicKey, a.PrivateKey); !errors.Is(err, ErrKeyMismatch) {
+		t.Fatalf("Expected ErrKeyMismatch, got %v", err)
+	}
+	if err := ValidateKeyPair(c.PublicKey, a.PrivateKey); !errors.Is(err, ErrKeyMismatch) {
+		t.Fatalf("Expected ErrKeyMismatch for different degrees, got %v", err)
+	}
+	if err := ValidateKeyPair(a.PrivateKey, a.PublicKey); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("Expected ErrBadFormat for swapped keys, got %v", err)
+	}
+}
+
+func TestVerifyKeyPair(t *testing.T) {
+	a, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	b, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	if err := VerifyKeyPair(a.PrivateKey, a.PublicKey); err != nil {
+		t.Fatalf("Matching key pair rejected: %v", err)
+	}
+	if err := VerifyKeyPair(a.PrivateKey, b.PublicKey); !errors.Is(err, ErrKeyMismatch) {
+		t.Fatalf("Expected ErrKeyMismatch, got %v", err)
+	}
+	if err := VerifyKeyPair(a.PrivateKey[:10], a.PublicKey); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("Expected ErrBadFormat for a truncated private key, got %v", err)
+	}
+	if err := VerifyKeyPair(a.PrivateKey, a.PublicKey[:10]); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("Expected ErrBadFormat for a truncated public key, got %v", err)
+	}
+}
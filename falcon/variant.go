@@ -0,0 +1,65 @@
+package falcon
+
+import "fmt"
+
+// Variant is a Falcon parameter set of fixed degree. Its methods take no
+// logN and reject keys and signatures of any other degree, which keeps
+// call sites self-documenting. Use the Falcon512 and Falcon1024 values.
+type Variant struct {
+	logN uint
+}
+
+// The standardized Falcon parameter sets
+var (
+	Falcon512  = Variant{logN: 9}
+	Falcon1024 = Variant{logN: 10}
+)
+
+// LogN returns the degree (log2) of the variant
+func (v Variant) LogN() uint {
+	return v.logN
+}
+
+// String returns the variant name, e.g. "Falcon-512"
+func (v Variant) String() string {
+	return fmt.Sprintf("Falcon-%d", 1<<v.logN)
+}
+
+// checkDegree rejects an object of degree logN for this variant
+func (v Variant) checkDegree(logN uint, what string) error {
+	if logN != v.logN {
+		return fmt.Errorf("%w: Falcon-%d %s used with %s", ErrUnsupportedDegree, 1<<logN, what, v)
+	}
+	return nil
+}
+
+// GenerateKeyPair generates a key pair of the variant's degree
+func (v Variant) GenerateKeyPair() (*KeyPair, error) {
+	return GenerateKeyPair(v.logN)
+}
+
+// Sign signs message with privateKey, which must be of the variant's
+// degree
+func (v Variant) Sign(message, privateKey []byte, sigType int) ([]byte, error) {
+	logN, err := checkPrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	if err := v.checkDegree(logN, "private key"); err != nil {
+		return nil, err
+	}
+	return Sign(message, privateKey, SignatureType(sigType))
+}
+
+// Verify verifies signature with publicKey, both of which must be of the
+// variant's degree
+func (v Variant) Verify(signature, message, publicKey []byte, sigType int) error {
+	logN, err := checkPublicKey(publicKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	if err := v.checkDegree(logN, "public key"); err != nil {
+		return err
+	}
+	return Verify(signature, message, publicKey, SignatureType(sigType))
+}
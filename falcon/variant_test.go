@@ -0,0 +1,50 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVariants(t *testing.T) {
+	message := []byte("variant")
+	keys := map[Variant]*KeyPair{}
+	for _, v := range []Variant{Falcon512, Falcon1024} {
+		kp, err := v.GenerateKeyPair()
+		if err != nil {
+			t.Fatalf("%s: failed to generate key pair: %v", v, err)
+		}
+		if logN, _ := kp.LogN(); uint(logN) != v.LogN() {
+			t.Fatalf("%s: generated a key of logN %d", v, logN)
+		}
+		signature, err := v.Sign(message, kp.PrivateKey, SigCompressed)
+		if err != nil {
+			t.Fatalf("%s: failed to sign message: %v", v, err)
+		}
+		if err := v.Verify(signature, message, kp.PublicKey, SigCompressed); err != nil {
+			t.Fatalf("%s: signature failed verification: %v", v, err)
+		}
+		keys[v] = kp
+	}
+
+	if Falcon512.String() != "Falcon-512" || Falcon1024.String() != "Falcon-1024" {
+		t.Fatalf("Unexpected variant names %s, %s", Falcon512, Falcon1024)
+	}
+
+	// The 512 wrapper rejects 1024 keys and vice versa
+	k1024 := keys[Falcon1024]
+	if _, err := Falcon512.Sign(message, k1024.PrivateKey, SigCompressed); !errors.Is(err, ErrUnsupportedDegree) {
+		t.Fatalf("Expected ErrUnsupportedDegree signing with a 1024 key, got %v", err)
+	}
+	signature, err := Falcon1024.Sign(message, k1024.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	if err := Falcon512.Verify(signature, message, k1024.PublicKey, SigCompressed); !errors.Is(err, ErrUnsupportedDegree) {
+		t.Fatalf("Expected ErrUnsupportedDegree verifying with a 1024 key, got %v", err)
+	}
+	if _, err := Falcon1024.Sign(message, keys[Falcon512].PrivateKey, SigCompressed); !errors.Is(err, ErrUnsupportedDegree) {
+		t.Fatalf("Expected ErrUnsupportedDegree signing with a 512 key, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package falcon
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrFormatNotAllowed is returned by VerifyStrictFormat when the
+// signature is encoded in a format other than the required one
+var ErrFormatNotAllowed = errors.New("signature format not allowed")
+
+// ErrNoMatchingMessage is returned by FindSignedMessage when the
+// signature covers none of the candidates
+var ErrNoMatchingMessage = errors.New("signature matches none of the candidate messages")
+
+// SignaturesCoverSameMessage reports whether both signatures are valid
+// signatures of message under publicKey. Signature bytes are randomized,
+// so two signatures of the same message never compare equal; this is the
+// correct way to check that they cover the same content. A signature
+// that does not verify yields false with a nil error; malformed inputs
+// yield an error matching ErrBadFormat, and any other failure is
+// returned as is.
+func SignaturesCoverSameMessage(sig1, sig2, message, publicKey []byte, sigType int) (bool, error) {
+	for _, sig := range [][]byte{sig1, sig2} {
+		if err := Verify(sig, message, publicKey, SignatureType(sigType)); err != nil {
+			if errors.Is(err, ErrBadSignature) {
+				return false, nil
+			}
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// VerifyStrictFormat verifies signature only if its encoding, as read
+// from the header and length, is requiredType. Unlike Verify, which
+// trusts the caller's sigType, this pins the format so a signature
+// cannot be re-framed into another encoding (for instance to move a
+// verifier off the constant-time path). A mismatch returns
+// ErrFormatNotAllowed without verifying.
+//
+// A compressed signature that happens to be exactly the padded length
+// is detected as padded (see DetectSigType). When SigCompressed is
+// required, such a signature is still verified as compressed, which
+// rejects a genuinely padded one, with its zero tail, as
+// ErrFormatNotAllowed.
+func VerifyStrictFormat(signature, message, publicKey []byte, requiredType int) error {
+	switch requiredType {
+	case SigCompressed, SigPadded, SigCT:
+	default:
+		return errInvalidSigType
+	}
+
+	actual, err := DetectSigType(signature)
+	if err != nil {
+		return fmt.Errorf("malformed signature: %w", err)
+	}
+	if actual == SigPadded && requiredType == SigCompressed {
+		err := Verify(signature, message, publicKey, SigCompressed)
+		if errors.Is(err, ErrInvalidFormat) {
+			return fmt.Errorf("%w: got type %d, want %d", ErrFormatNotAllowed, actual, requiredType)
+		}
+		return err
+	}
+	if actual != requiredType {
+		return fmt.Errorf("%w: got type %d, want %d", ErrFormatNotAllowed, actual, requiredType)
+	}
+	return Verify(signature, message, publicKey, SignatureType(requiredType))
+}
+
+// VerifyAuto verifies signature without being told its type, which is
+// read from the signature with DetectSigType. Use it when the type was
+// not stored alongside the signature; when it is known, Verify or
+// VerifyStrictFormat pin it.
+func VerifyAuto(signature, message, publicKey []byte) error {
+	sigType, err := DetectSigType(signature)
+	if err != nil {
+		return fmt.Errorf("malformed signature: %w", err)
+	}
+	return Verify(signature, message, publicKey, SignatureType(sigType))
+}
+
+// VerifyTimed verifies signature like Verify and also returns the wall
+// time the call took, for latency monitoring. The duration is reported
+// whether or not verification succeeds.
+func VerifyTimed(signature, message, publicKey []byte, sigType int) (time.Duration, error) {
+	start := time.Now()
+	err := Verify(signature, message, publicKey, SignatureType(sigType))
+	return time.Since(start), err
+}
+
+// FindSignedMessage returns the index of the first candidate message
+// that signature is valid for under publicKey, or -1 and
+// ErrNoMatchingMessage if there is none. The inputs are checked once and
+// a single scratch buffer is shared by all attempts. A failure other
+// than a signature mismatch stops the search and is returned.
+func FindSignedMessage(signature, publicKey []byte, sigType int, candidates [][]byte) (int, error) {
+	logN, err := checkPublicKey(publicKey)
+	if err != nil {
+		return -1, fmt.Errorf("invalid public key: %w", err)
+	}
+	if _, err := checkSignature(signature, sigType); err != nil {
+		return -1, fmt.Errorf("malformed signature: %w", err)
+	}
+
+	tmp := make([]byte, tmpSizeVerify(logN))
+	for i, message := range candidates {
+		err := verifyWithTmp(signature, message, publicKey, sigType, tmp)
+		if err == nil {
+			return i, nil
+		}
+		if !errors.Is(err, ErrBadSignature) {
+			return -1, err
+		}
+	}
+	return -1, ErrNoMatchingMessage
+}
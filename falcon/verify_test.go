@@ -0,0 +1,228 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVerifyKnownLogN(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	message := []byte("known degree")
+	signature, err := Sign(message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	if err := VerifyKnownLogN(signature, message, keyPair.PublicKey, SigCompressed, 9); err != nil {
+		t.Fatalf("Signature verification failed: %v", err)
+	}
+
+	// A degree that disagrees with the key length must be rejected
+	if err := VerifyKnownLogN(signature, message, keyPair.PublicKey, SigCompressed, 10); err == nil {
+		t.Fatal("Expected error for inconsistent logN")
+	}
+	if err := VerifyKnownLogN(signature, message, keyPair.PublicKey, SigCompressed, 0); err == nil {
+		t.Fatal("Expected error for out-of-range logN")
+	}
+	if err := VerifyKnownLogN(signature, message, keyPair.PublicKey[:len(keyPair.PublicKey)-1], SigCompressed, 9); err == nil {
+		t.Fatal("Expected error for truncated public key")
+	}
+
+	tampered := append([]byte{}, message...)
+	tampered[0] ^= 1
+	if err := VerifyKnownLogN(signature, tampered, keyPair.PublicKey, SigCompressed, 9); err == nil {
+		t.Fatal("Verification succeeded for modified message")
+	}
+}
+
+func TestSignaturesCoverSameMessage(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	message := []byte("shared content")
+	sig1, err := Sign(message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	sig2, err := Sign(message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	other, err := Sign([]byte("other content"), keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	same, err := SignaturesCoverSameMessage(sig1, sig2, message, keyPair.PublicKey, SigCompressed)
+	if err != nil || !same {
+		t.Fatalf("Expected signatures of the same message to match: same=%v err=%v", same, err)
+	}
+
+	same, err = SignaturesCoverSameMessage(sig1, other, message, keyPair.PublicKey, SigCompressed)
+	if err != nil || same {
+		t.Fatalf("Expected signature of another message not to match: same=%v err=%v", same, err)
+	}
+
+	if _, err := SignaturesCoverSameMessage(sig1, other[:10], message, keyPair.PublicKey, SigCompressed); err == nil {
+		t.Fatal("Expected error for malformed signature")
+	}
+}
+
+func TestVerifyStrictFormat(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	message := []byte("pinned format")
+	sigs := map[int][]byte{}
+	for _, sigType := range []int{SigCompressed, SigPadded, SigCT} {
+		sig, err := Sign(message, keyPair.PrivateKey, SignatureType(sigType))
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		sigs[sigType] = sig
+	}
+
+	for sigType, sig := range sigs {
+		if err := VerifyStrictFormat(sig, message, keyPair.PublicKey, sigType); err != nil {
+			t.Fatalf("Signature of type %d failed strict verification: %v", sigType, err)
+		}
+		for required := range sigs {
+			if required == sigType {
+				continue
+			}
+			err := VerifyStrictFormat(sig, message, keyPair.PublicKey, required)
+			if !errors.Is(err, ErrFormatNotAllowed) {
+				t.Fatalf("Type %d signature required as %d: expected ErrFormatNotAllowed, got %v", sigType, required, err)
+			}
+		}
+	}
+
+	// A compressed signature of exactly the padded length is detected as
+	// padded but still passes when compressed is required. Such lengths
+	// are common enough at logN 2 to find one among seeded signatures.
+	small, err := GenerateKeyPairFromSeed(2, make([]byte, 32))
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	var ambiguous []byte
+	for i := 0; i < 20000 && ambiguous == nil; i++ {
+		seed := make([]byte, 32)
+		seed[0], seed[1] = byte(i), byte(i>>8)
+		sig, err := SignDeterministic(message, small.PrivateKey, SigCompressed, seed)
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		if len(sig) == sigPaddedSize(2) {
+			ambiguous = sig
+		}
+	}
+	if ambiguous == nil {
+		t.Fatal("No compressed signature of padded length found")
+	}
+	if detected, _ := DetectSigType(ambiguous); detected != SigPadded {
+		t.Fatalf("Expected the signature to be detected as padded, got %d", detected)
+	}
+	if err := VerifyStrictFormat(ambiguous, message, small.PublicKey, SigCompressed); err != nil {
+		t.Fatalf("Compressed signature of padded length failed strict verification: %v", err)
+	}
+
+	if err := VerifyStrictFormat(sigs[SigCT], message, keyPair.PublicKey, 0); err == nil {
+		t.Fatal("Expected error for unspecified required type")
+	}
+	if err := VerifyStrictFormat(sigs[SigCT][:10], message, keyPair.PublicKey, SigCT); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("Expected ErrBadFormat for truncated signature, got %v", err)
+	}
+}
+
+func TestVerifyTimed(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	message := []byte("timed")
+	signature, err := Sign(message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	elapsed, err := VerifyTimed(signature, message, keyPair.PublicKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Signature verification failed: %v", err)
+	}
+	if elapsed <= 0 {
+		t.Fatalf("Expected positive duration, got %v", elapsed)
+	}
+
+	if _, err := VerifyTimed(signature, []byte("other"), keyPair.PublicKey, SigCompressed); err == nil {
+		t.Fatal("Verification succeeded for modified message")
+	}
+}
+
+func TestFindSignedMessage(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+
+	candidates := [][]byte{
+		[]byte("invoice 1"),
+		[]byte("invoice 2"),
+		{},
+		[]byte("invoice 3"),
+	}
+	signature, err := Sign(candidates[3], keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	idx, err := FindSignedMessage(signature, keyPair.PublicKey, SigCompressed, candidates)
+	if err != nil {
+		t.Fatalf("Failed to find signed message: %v", err)
+	}
+	if idx != 3 {
+		t.Fatalf("Expected index 3, got %d", idx)
+	}
+
+	idx, err = FindSignedMessage(signature, keyPair.PublicKey, SigCompressed, candidates[:3])
+	if !errors.Is(err, ErrNoMatchingMessage) || idx != -1 {
+		t.Fatalf("Expected -1 and ErrNoMatchingMessage, got %d, %v", idx, err)
+	}
+
+	if _, err := FindSignedMessage(signature[:5], keyPair.PublicKey, SigCompressed, candidates); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("Expected ErrBadFormat for truncated signature, got %v", err)
+	}
+}
+
+func TestVerifyAuto(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	message := []byte("stored without its type")
+	for _, sigType := range []int{SigCompressed, SigPadded, SigCT} {
+		signature, err := Sign(message, keyPair.PrivateKey, SignatureType(sigType))
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		if err := VerifyAuto(signature, message, keyPair.PublicKey); err != nil {
+			t.Fatalf("Type %d: VerifyAuto failed: %v", sigType, err)
+		}
+		if err := VerifyAuto(signature, []byte("other"), keyPair.PublicKey); !errors.Is(err, ErrBadSignature) {
+			t.Fatalf("Type %d: expected ErrBadSignature, got %v", sigType, err)
+		}
+	}
+	if err := VerifyAuto([]byte{0x70}, message, keyPair.PublicKey); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("Expected ErrBadFormat, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package falcon
+
+import (
+	"fmt"
+	"io"
+)
+
+// ErrWriterClosed is returned by SignWriter.Write after Close
+var ErrWriterClosed = newError(ErrBadArg, "sign writer already closed")
+
+// ErrSignatureNotReady is returned by SignWriter.Signature before Close
+var ErrSignatureNotReady = newError(ErrBadArg, "signature not finalized")
+
+// SignWriter signs a message written to it in pieces. Each Write is
+// absorbed straight into the SHAKE256 message hash, after the nonce,
+// exactly as Sign hashes a message held in memory, so nothing is
+// buffered and the resulting signature is a standard Falcon signature
+// that verifies with Verify (or VerifyStream) over the concatenation of
+// everything written. It is not safe for concurrent use.
+type SignWriter struct {
+	privateKey []byte
+	sigType    int
+	rng        *PRNGContext
+	hashData   PRNGContext
+	nonce      []byte
+	closed     bool
+	signature  []byte
+	err        error
+}
+
+var _ io.WriteCloser = (*SignWriter)(nil)
+
+// NewSignWriter returns a SignWriter for privateKey. Randomness for the
+// nonce and the signature is drawn from rng, which must be in output
+// mode, as for SignWithRNG, and is advanced; a nil rng uses a fresh
+// system-seeded one. The private key is copied.
+func NewSignWriter(privateKey []byte, sigType int, rng *PRNGContext) (*SignWriter, error) {
+	logN, err := checkPrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	if _, err := sigBufferSize(logN, sigType); err != nil {
+		return nil, err
+	}
+	if rng != nil && !rng.flipped {
+		return nil, ErrContextNotFlipped
+	}
+	if rng == nil {
+		if rng, err = newSystemPRNG(); err != nil {
+			return nil, fmt.Errorf("failed to initialize RNG: %w", err)
+		}
+	}
+
+	w := &SignWriter{
+		privateKey: append([]byte{}, privateKey...),
+		sigType:    sigType,
+		rng:        rng,
+	}
+	w.nonce = signStart(rng, &w.hashData)
+	return w, nil
+}
+
+// Write absorbs p into the message hash. It never returns a short count.
+func (w *SignWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, ErrWriterClosed
+	}
+	w.hashData.Inject(p)
+	return len(p), nil
+}
+
+// Close finalizes the signature. The result, or the signing error, is
+// then available from Signature; Close also returns the error. Calling
+// Close again has no effect.
+func (w *SignWriter) Close() error {
+	if w.closed {
+		return w.err
+	}
+	w.closed = true
+	w.signature, w.err = signFinish(w.rng, w.privateKey, w.sigType, &w.hashData, w.nonce)
+	SecureZero(w.privateKey)
+	return w.err
+}
+
+// Signature returns the signature over everything written, or
+// ErrSignatureNotReady if Close has not been called yet
+func (w *SignWriter) Signature() ([]byte, error) {
+	if !w.closed {
+		return nil, ErrSignatureNotReady
+	}
+	if w.err != nil {
+		return nil, w.err
+	}
+	return append([]byte{}, w.signature...), nil
+}
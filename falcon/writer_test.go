@@ -0,0 +1,77 @@
+//go:build cgo
+
+package falcon
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestSignWriter(t *testing.T) {
+	keyPair, err := GenerateKeyPair(9)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	message := bytes.Repeat([]byte("firmware image block "), 50000)
+
+	for _, sigType := range []int{SigCompressed, SigPadded, SigCT} {
+		w, err := NewSignWriter(keyPair.PrivateKey, sigType, nil)
+		if err != nil {
+			t.Fatalf("Failed to create sign writer: %v", err)
+		}
+		if _, err := w.Signature(); !errors.Is(err, ErrSignatureNotReady) {
+			t.Fatalf("Expected ErrSignatureNotReady, got %v", err)
+		}
+		// Uneven chunks must not change the result
+		if _, err := io.CopyBuffer(w, bytes.NewReader(message), make([]byte, 1000)); err != nil {
+			t.Fatalf("Failed to write message: %v", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("Failed to finalize signature: %v", err)
+		}
+		if _, err := w.Write([]byte("late")); !errors.Is(err, ErrWriterClosed) {
+			t.Fatalf("Expected ErrWriterClosed, got %v", err)
+		}
+
+		signature, err := w.Signature()
+		if err != nil {
+			t.Fatalf("Failed to get signature: %v", err)
+		}
+		if err := Verify(signature, message, keyPair.PublicKey, SignatureType(sigType)); err != nil {
+			t.Fatalf("Type %d: writer signature failed verification: %v", sigType, err)
+		}
+	}
+
+	// The same seeded rng gives the same signature as Sign's path
+	var rng1, rng2 PRNGContext
+	rng1.InitFromSeed([]byte("writer seed"))
+	rng2.InitFromSeed([]byte("writer seed"))
+	w, err := NewSignWriter(keyPair.PrivateKey, SigCompressed, &rng1)
+	if err != nil {
+		t.Fatalf("Failed to create sign writer: %v", err)
+	}
+	w.Write(message)
+	w.Close()
+	got, _ := w.Signature()
+	want, err := signWithPRNG(&rng2, message, keyPair.PrivateKey, SigCompressed)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatal("Writer signature differs from one-shot signature with the same rng")
+	}
+
+	if _, err := NewSignWriter(keyPair.PrivateKey[:10], SigCompressed, nil); err == nil {
+		t.Fatal("Expected error for truncated private key")
+	}
+	if _, err := NewSignWriter(keyPair.PrivateKey, 9, nil); err == nil {
+		t.Fatal("Expected error for invalid signature type")
+	}
+	var unflipped PRNGContext
+	unflipped.Init()
+	if _, err := NewSignWriter(keyPair.PrivateKey, SigCompressed, &unflipped); !errors.Is(err, ErrContextNotFlipped) {
+		t.Fatalf("Expected ErrContextNotFlipped, got %v", err)
+	}
+}